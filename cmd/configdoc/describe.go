@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/jamillosantos/config"
+)
+
+// describeProgram is the program describing the config struct: it imports the package declaring the struct and prints
+// its config.DocFields as JSON.
+var describeProgram = template.Must(template.New("main.go").Parse(`package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jamillosantos/config"
+
+	target {{ printf "%q" .ImportPath }}
+)
+
+func main() {
+	fields, err := config.DocFields(&target.{{ .TypeName }}{})
+	if err == nil {
+		err = json.NewEncoder(os.Stdout).Encode(fields)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// describeFields describes the fields of the struct typeName, declared by the package in dir, with config.DocFields,
+// so they follow the rules of Populate exactly. The struct is described by a program generated in a temporary
+// directory of dir, which is run with the go command from dir so it builds in the module of the package.
+func describeFields(dir, typeName string) ([]config.DocField, error) {
+	if err := findType(dir, typeName); err != nil {
+		return nil, err
+	}
+	out, err := goCommand(dir, "list", "-f", "{{.ImportPath}} {{.Name}}", ".")
+	if err != nil {
+		return nil, err
+	}
+	importPath, name, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	if name == "main" {
+		return nil, fmt.Errorf("type %s is declared by a main package, which cannot be imported", typeName)
+	}
+
+	tmp, err := os.MkdirTemp(dir, ".configdoc")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	var src bytes.Buffer
+	err = describeProgram.Execute(&src, struct{ ImportPath, TypeName string }{importPath, typeName})
+	if err != nil {
+		return nil, err
+	}
+	program, err := filepath.Abs(filepath.Join(tmp, "main.go"))
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(program, src.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+
+	out, err = goCommand(dir, "run", program)
+	if err != nil {
+		return nil, err
+	}
+	var fields []config.DocField
+	if err := json.Unmarshal(out, &fields); err != nil {
+		return nil, fmt.Errorf("reading the fields of %s: %w", typeName, err)
+	}
+	return fields, nil
+}
+
+// findType checks that the package in dir declares the exported type typeName, so the generated program builds.
+func findType(dir, typeName string) error {
+	if !token.IsExported(typeName) {
+		return fmt.Errorf("type %s is not exported", typeName)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+				for _, spec := range decl.Specs {
+					if spec.(*ast.TypeSpec).Name.Name == typeName {
+						return nil
+					}
+				}
+			}
+		}
+	}
+	return fmt.Errorf("type %s not found in %s", typeName, dir)
+}
+
+// goCommand runs the go command with args from dir and returns its output.
+func goCommand(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
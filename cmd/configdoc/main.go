@@ -0,0 +1,62 @@
+// Command configdoc generates the documentation of a config struct: a Markdown table of its keys and environment
+// variables, a `.env.example` file or a sample YAML file. The struct, which must be exported, is described by
+// config.DocFields, from a program the command generates and runs with the go command in the module of its package, so
+// the command can run from `go generate`:
+//
+//	//go:generate go run github.com/jamillosantos/config/cmd/configdoc -type Config -prefix APP_ -o CONFIG.md
+//	//go:generate go run github.com/jamillosantos/config/cmd/configdoc -type Config -prefix APP_ -format env -o .env.example
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jamillosantos/config"
+)
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.typeName, "type", "", "name of the config struct (required)")
+	flag.StringVar(&opts.dir, "dir", ".", "directory of the package declaring the config struct")
+	flag.StringVar(&opts.format, "format", string(config.DocMarkdown), "output format: markdown, env or yaml")
+	flag.StringVar(&opts.prefix, "prefix", "", "prefix of the environment variables, like APP_")
+	flag.StringVar(&opts.separator, "separator", ".", "separator of the nested keys")
+	flag.StringVar(&opts.output, "o", "", "output file (default stdout)")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, "configdoc:", err)
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	typeName  string
+	dir       string
+	format    string
+	prefix    string
+	separator string
+	output    string
+}
+
+func run(opts options) error {
+	if opts.typeName == "" {
+		return errors.New("-type is required")
+	}
+	fields, err := describeFields(opts.dir, opts.typeName)
+	if err != nil {
+		return err
+	}
+	manager := config.NewManager(config.WithKeySeparator(opts.separator))
+	data, err := manager.RenderDocs(fields, config.DocFormat(opts.format), config.WithPrefix(opts.prefix))
+	if err != nil {
+		return err
+	}
+	if opts.output == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(opts.output, data, 0o644)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jamillosantos/config"
+	"github.com/jamillosantos/config/cmd/configdoc/testdata/app"
+)
+
+func TestDescribeFields(t *testing.T) {
+	fields, err := describeFields("testdata/app", "Config")
+	require.NoError(t, err)
+
+	want, err := config.DocFields(&app.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, want, fields)
+
+	t.Run("should describe the fields as Populate reads them", func(t *testing.T) {
+		byName := make(map[string]config.DocField, len(fields))
+		for _, field := range fields {
+			byName[field.Name] = field
+		}
+		assert.Equal(t, config.DocField{Name: "started", Kind: config.DocValue, Type: "time.Time"}, byName["started"])
+		assert.Equal(t, config.DocField{Name: "allowed", Kind: config.DocList, Type: "[]net.IP", Text: true}, byName["allowed"])
+		assert.NotContains(t, byName, "ignored")
+	})
+
+	t.Run("should fail on unexported types", func(t *testing.T) {
+		_, err := describeFields("testdata/app", "config")
+		require.EqualError(t, err, "type config is not exported")
+	})
+}
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), ".env.example")
+	require.NoError(t, run(options{
+		typeName:  "Config",
+		dir:       "testdata/app",
+		format:    string(config.DocEnvExample),
+		prefix:    "APP_",
+		separator: ".",
+		output:    output,
+	}))
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# Name of the application.\n# string\nAPP_NAME=app\n"))
+	assert.Contains(t, string(data), "# Host of the server.\n# string, required\nAPP_SERVERS_0_HOST=\n")
+	assert.NotContains(t, string(data), "UPSTREAMS")
+
+	t.Run("should fail on unknown types", func(t *testing.T) {
+		err := run(options{typeName: "Missing", dir: "testdata/app", format: string(config.DocMarkdown)})
+		require.EqualError(t, err, "type Missing not found in testdata/app")
+	})
+}
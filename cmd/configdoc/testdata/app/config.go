@@ -0,0 +1,46 @@
+package app
+
+import (
+	"net"
+	"time"
+
+	bs "github.com/inhies/go-bytesize"
+)
+
+type Level int
+
+func (l *Level) UnmarshalText(text []byte) error {
+	return nil
+}
+
+type Hosts []string
+
+type Server struct {
+	Host string `config:"host,required" desc:"Host of the server."`
+	Port int    `config:"port" default:"80"`
+}
+
+type Node struct {
+	Name     string  `config:"name"`
+	Children []*Node `config:"children"`
+}
+
+type Config struct {
+	Name      string            `config:"name" default:"app" desc:"Name of the application."`
+	Level     Level             `config:"level" default:"info"`
+	Timeout   time.Duration     `config:"timeout" default:"30s"`
+	MaxBody   bs.ByteSize       `config:"max_body"`
+	Hosts     Hosts             `config:"hosts"`
+	Labels    map[string]string `config:"labels"`
+	Password  string            `config:"password,secret,required"`
+	Primary   Server            `config:"primary"`
+	Backup    *Server           `config:"backup"`
+	Servers   []Server          `config:"servers"`
+	Upstreams map[string]Server `config:"upstreams"`
+	Tree      Node              `config:"tree"`
+	Started   time.Time         `config:"started,layout=unix"`
+	Allowed   []net.IP          `config:"allowed"`
+	Ignored   chan int          `config:"ignored"`
+	Untagged  string
+	Skipped   string `config:"-"`
+}
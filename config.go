@@ -7,8 +7,12 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 )
 
 type configLoadOptions struct {
@@ -16,43 +20,89 @@ type configLoadOptions struct {
 	Secrets []string `json:"secrets"`
 }
 
+// fileEngines maps the formats accepted by the "<format>file:" and "<format>fileenv:" load options tokens to the
+// constructor of the engine that reads them.
+var fileEngines = map[string]func(loader Loader) Engine{
+	"yaml":       func(loader Loader) Engine { return NewYAMLEngine(loader) },
+	"json":       func(loader Loader) Engine { return NewJSONEngine(loader) },
+	"toml":       func(loader Loader) Engine { return NewTOMLEngine(loader) },
+	"dotenv":     func(loader Loader) Engine { return NewDotenvEngine(loader) },
+	"ini":        func(loader Loader) Engine { return NewINIEngine(loader) },
+	"properties": func(loader Loader) Engine { return NewPropertiesEngine(loader) },
+}
+
 // buildEnginesFromOptions builds a list of engines from a list of token strings.
 //
 // Supported tokens:
 //   - "env": creates a new EnvEngine.
-//   - "yamlfile:<filepath>": creates a new YAMLEngine backed by the given file path.
-//   - "yamlfileenv:<ENV>": creates a new YAMLEngine backed by the file path read from the named env var.
+//   - "<format>file:<filepath>": creates a new engine for the format backed by the given file path.
+//   - "<format>fileenv:<ENV>": creates a new engine for the format backed by the file path read from the named env var.
+//   - "dir:<path>": creates a new DirEngine reading the files of the given directory.
+//   - "systemdcreds": creates a new SystemdCredentialsEngine.
+//
+// The supported formats are "yaml" (YAMLEngine), "json" (JSONEngine), "toml" (TOMLEngine), "dotenv" (DotenvEngine),
+// "ini" (INIEngine) and "properties" (PropertiesEngine).
 func buildEnginesFromOptions(options []string) ([]Engine, error) {
 	result := make([]Engine, 0, len(options))
 	for _, opt := range options {
+		token, arg, _ := strings.Cut(opt, ":")
 		switch {
 		case opt == "env":
 			eng := NewEnvEngine()
 			result = append(result, &eng)
-		case strings.HasPrefix(opt, "yamlfile:"):
-			filePath := strings.TrimPrefix(opt, "yamlfile:")
-			result = append(result, NewYAMLEngine(NewFileLoader(filePath)))
-		case strings.HasPrefix(opt, "yamlfileenv:"):
-			envName := strings.TrimPrefix(opt, "yamlfileenv:")
-			filePath, ok := os.LookupEnv(envName)
-			if !ok || filePath == "" {
-				return nil, fmt.Errorf("yamlfileenv: environment variable %q is not set", envName)
+		case token == "dir":
+			result = append(result, NewDirEngine(arg))
+		case opt == "systemdcreds":
+			result = append(result, NewSystemdCredentialsEngine())
+		case strings.HasSuffix(token, "fileenv"):
+			newEngine, ok := fileEngines[strings.TrimSuffix(token, "fileenv")]
+			if !ok {
+				continue
+			}
+			filePath, err := filePathFromEnv(token, arg)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, newEngine(NewFileLoader(filePath)))
+		case strings.HasSuffix(token, "file"):
+			newEngine, ok := fileEngines[strings.TrimSuffix(token, "file")]
+			if !ok {
+				continue
 			}
-			result = append(result, NewYAMLEngine(NewFileLoader(filePath)))
+			result = append(result, newEngine(NewFileLoader(arg)))
 		}
 	}
 	return result, nil
 }
 
+// filePathFromEnv reads a file path from the envName environment variable. The token is only used to give context
+// to the error returned when the variable is not set.
+func filePathFromEnv(token, envName string) (string, error) {
+	filePath, ok := os.LookupEnv(envName)
+	if !ok || filePath == "" {
+		return "", fmt.Errorf("%s: environment variable %q is not set", token, envName)
+	}
+	return filePath, nil
+}
+
 const (
 	defaultKeySeparator = "."
 )
 
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
 type Validator interface {
 	Validate() error
 }
 
 type Manager struct {
+	// mu serializes the Populate calls, which load the engines, so configs can be reloaded while being populated.
+	mu sync.Mutex
+
 	init           sync.Once
 	keySeparator   string
 	secrets        []Engine
@@ -60,6 +110,14 @@ type Manager struct {
 	loadOptionsEnv string
 	loadOptions    *configLoadOptions
 	loadOptionsErr error
+
+	collectAllErrors bool
+
+	// noInterpolation disables the expansion of the references in the values, set by WithoutInterpolation.
+	noInterpolation bool
+
+	// decoders holds the decoders registered by RegisterDecoder.
+	decoders decoderRegistry
 }
 
 type Option func(*Manager)
@@ -100,6 +158,24 @@ func WithLoadOptionsEnv(envName string) Option {
 	}
 }
 
+// WithCollectAllErrors makes Populate go on when a field fails, instead of returning on the first failure. All the
+// missing required keys, the values that cannot be read into their fields and the Validator failures are then
+// returned together as FieldErrors. The structs are validated even when some of their fields failed, so their
+// Validator sees these fields unset.
+func WithCollectAllErrors() Option {
+	return func(m *Manager) {
+		m.collectAllErrors = true
+	}
+}
+
+// WithoutInterpolation makes Populate read the values as they are, without expanding their `${...}` references, as the
+// `nointerpolate` tag option does for a single field.
+func WithoutInterpolation() Option {
+	return func(m *Manager) {
+		m.noInterpolation = true
+	}
+}
+
 func (m *Manager) initializeEngines() {
 	if m.secrets != nil || m.plains != nil {
 		m.secrets = make([]Engine, 0)
@@ -109,15 +185,39 @@ func (m *Manager) initializeEngines() {
 
 func (m *Manager) AddSecretEngine(engines ...Engine) {
 	m.init.Do(m.initializeEngines)
+	m.attach(engines)
 	m.secrets = append(m.secrets, engines...)
 }
 
 func (m *Manager) AddPlainEngine(engines ...Engine) {
 	m.init.Do(m.initializeEngines)
+	m.attach(engines)
 	m.plains = append(m.plains, engines...)
 }
 
+// managedEngine is implemented by the engines that depend on the options of the Manager they are added to, like its
+// key separator.
+type managedEngine interface {
+	setManager(m *Manager)
+}
+
+// attach sets m as the Manager of the engines that implement managedEngine.
+func (m *Manager) attach(engines []Engine) {
+	for _, engine := range engines {
+		if managed, ok := engine.(managedEngine); ok {
+			managed.setManager(m)
+		}
+	}
+}
+
 func (m *Manager) Populate(cfg interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.populate(cfg, nil)
+}
+
+// populate populates cfg, adding its fields to report when it is not nil.
+func (m *Manager) populate(cfg interface{}, report *Report) error {
 	if m.loadOptionsErr != nil {
 		return m.loadOptionsErr
 	}
@@ -154,283 +254,926 @@ func (m *Manager) Populate(cfg interface{}) error {
 	if reflect.ValueOf(cfg).Kind() != reflect.Ptr {
 		return ErrConfigNotPointer
 	}
-	return m.unmarshalObj("", cfg)
+	p := &populator{Manager: m, report: report}
+	if err := p.unmarshalObj(fieldRef{}, cfg); err != nil {
+		return err
+	}
+	if len(p.errs) > 0 {
+		return p.errs
+	}
+	return nil
+}
+
+// populator populates a config struct from the engines of a Manager, holding the state of a single Populate call.
+type populator struct {
+	*Manager
+	errs   FieldErrors
+	report *Report
+}
+
+// fieldRef locates a field of the config struct. The key is the one read from the engines, the path is how the key
+// is shown in the errors, which differs from the key for the elements of slices: the key `servers.1` is shown as
+// `servers[1]`, and the field is the Go path of the field, like `Servers[1].Port`.
+type fieldRef struct {
+	key   string
+	path  string
+	field string
+}
+
+// child returns the reference of the field named goName, read from the key name, nested under r.
+func (r fieldRef) child(separator, name, goName string) fieldRef {
+	if r.key == "" {
+		return fieldRef{key: name, path: name, field: goName}
+	}
+	return fieldRef{
+		key:   r.key + separator + name,
+		path:  r.path + separator + name,
+		field: r.field + "." + goName,
+	}
 }
 
-func (m *Manager) unmarshalObj(keyPrefix string, obj interface{}) error {
+// index returns the reference of the i-th element of the slice referenced by r.
+func (r fieldRef) index(separator string, i int) fieldRef {
+	return fieldRef{
+		key:   r.key + separator + strconv.Itoa(i),
+		path:  fmt.Sprintf("%s[%d]", r.path, i),
+		field: fmt.Sprintf("%s[%d]", r.field, i),
+	}
+}
+
+// entry returns the reference of the entry name of the map referenced by r.
+func (r fieldRef) entry(separator, name string) fieldRef {
+	return fieldRef{
+		key:   r.key + separator + name,
+		path:  r.path + separator + name,
+		field: fmt.Sprintf("%s[%q]", r.field, name),
+	}
+}
+
+// fail handles the error of the field referenced by ref. When the Manager collects all the errors, the error is
+// recorded as a FieldError and nil is returned, so the population goes on. Otherwise, err is returned as is.
+func (p *populator) fail(ref fieldRef, engine Engine, err error) error {
+	if !p.collectAllErrors {
+		return err
+	}
+	p.errs = append(p.errs, &FieldError{
+		Path:   ref.field,
+		Key:    ref.key,
+		Engine: engine,
+		Err:    err,
+	})
+	return nil
+}
+
+// failRead is fail for the errors returned while reading a value. When collecting all the errors, the values that
+// cannot be converted to the type of the field are reported as ErrTypeMismatch.
+func (p *populator) failRead(ref fieldRef, engine Engine, err error) error {
+	if p.collectAllErrors && !isReferenceError(err) && !errors.Is(err, ErrKeyNotFound) && !errors.Is(err, ErrTypeMismatch) {
+		err = fmt.Errorf("%w: %s: %w", ErrTypeMismatch, ref.path, err)
+	}
+	return p.fail(ref, engine, err)
+}
+
+// read reads key from engine into v, expanding the references in its value unless the Manager or the field disables
+// it.
+func (p *populator) read(engine Engine, key string, tag fieldTag, v reflect.Value) error {
+	if !p.noInterpolation && tag.interpolates() {
+		ref := key
+		if tag.secret {
+			ref = secretReferencePrefix + key
+		}
+		var err error
+		if engine, err = p.interpolate(engine, key, ref, tag.secret, v.Type()); err != nil {
+			return err
+		}
+	}
+	if v.Type() == timeType && tag.hasTimeOptions() {
+		return readTime(engine, key, tag, v)
+	}
+	return readValue(engine, key, v, p.decoders)
+}
+
+// readField reads the leaf field v from the first of the engines, or else from defaultEngine, that has its key. It
+// returns whether the key was found.
+func (p *populator) readField(engines []Engine, defaultEngine Engine, ref fieldRef, tag fieldTag, isRequired bool, v reflect.Value) (bool, error) {
+	if defaultEngine != nil {
+		engines = append(engines[:len(engines):len(engines)], defaultEngine)
+	}
+	var (
+		winner Engine
+		missed []Engine
+	)
+	engine, err := readFromEnginesInSequence(engines, ref.path, isRequired, func(engine Engine) error {
+		err := p.read(engine, ref.key, tag, v)
+		if err == nil {
+			winner = engine
+		} else if errors.Is(err, ErrKeyNotFound) {
+			missed = append(missed, engine)
+		}
+		return err
+	})
+	p.record(ref, tag, winner, missed)
+	if err != nil {
+		return false, p.failRead(ref, engine, err)
+	}
+	return winner != nil, nil
+}
+
+// unmarshalObj populates the struct pointed by obj with the keys nested under ref.
+func (p *populator) unmarshalObj(ref fieldRef, obj interface{}) error {
 	v := reflect.ValueOf(obj)
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
 	}
 	t := v.Type()
+	rules := validationRules(t)
 	for f := 0; f < v.NumField(); f++ {
 		fieldValue, fieldType := v.Field(f), t.Field(f)
-		configTag := fieldType.Tag.Get("config")
-		configTagTokens := strings.Split(configTag, ",")
-		propName := configTagTokens[0]
-		if propName == "-" || propName == "" {
+		tag, ok := parseFieldTag(fieldType)
+		if !ok {
 			continue
 		}
-		isRequired := false
-		isSecret := false
-		for _, tok := range configTagTokens[1:] {
-			switch tok {
-			case "required":
-				isRequired = true
-			case "secret":
-				isSecret = true
-			}
-		}
+		isRequired, isSecret := tag.required, tag.secret
 
-		if isSecret && len(m.secrets) == 0 {
+		if isSecret && len(p.secrets) == 0 {
 			return ErrNoSecretEngineDefined
 		}
 
-		if !isSecret && len(m.plains) == 0 {
+		if !isSecret && len(p.plains) == 0 {
 			return ErrNoPlainEngineDefined
 		}
 
-		engines := m.secrets // Default to secrets
+		engines := p.secrets // Default to secrets
 		if !isSecret {
-			engines = m.plains
+			engines = p.plains
 		}
 
-		if configTag != "" && len(m.plains) == 0 {
+		if len(p.plains) == 0 {
 			return ErrNoPlainEngineDefined
 		}
 
-		key := keyPrefix
-		if key != "" {
-			key += m.keySeparator
+		field := ref.child(p.keySeparator, tag.name, fieldType.Name)
+		key, path := field.key, field.path
+		if tag.err != nil {
+			if err := p.fail(field, nil, fmt.Errorf("%w: %s: %w", ErrInvalidConfigTag, path, tag.err)); err != nil {
+				return err
+			}
+			continue
 		}
-		key += propName
 
-		fieldTextUnmarshalerValue, okTextUnmarshalerValue := fieldValue.Addr().Interface().(encoding.TextUnmarshaler)
-		if okTextUnmarshalerValue {
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetString(key)
-				if err != nil {
-					return fmt.Errorf("%w: %s", err, key)
-				}
-				return fieldTextUnmarshalerValue.UnmarshalText([]byte(value))
-			})
-			switch {
-			case errors.Is(err, ErrTypeMismatch):
-				okTextUnmarshalerValue = false
-			case err != nil:
-				return fmt.Errorf("%w: %s", err, key)
-			}
+		var defaultEngine Engine
+		if tag.hasDefault {
+			// The default value is read by an engine of its own, tried after all the others, so it is parsed exactly
+			// as the values coming from text sources are.
+			defaultEngine = newDefaultEngine(key, tag.defaultValue)
 		}
+
+		// found is set when the field is set, so it is checked against its `validate` tag.
+		var found bool
 		switch {
-		case okTextUnmarshalerValue:
-			// Do nothing
+		case p.decoders.has(fieldValue.Type()):
+			var err error
+			if found, err = p.readField(engines, defaultEngine, field, tag, isRequired, fieldValue); err != nil {
+				return err
+			}
+		case fieldValue.Kind() == reflect.Ptr:
+			if err := p.unmarshalPtr(engines, defaultEngine, field, tag, fieldValue); err != nil {
+				return err
+			}
+			found = !fieldValue.IsNil()
+		case p.decoders.isLeafType(fieldValue.Type()):
+			var err error
+			if found, err = p.readField(engines, defaultEngine, field, tag, isRequired, fieldValue); err != nil {
+				return err
+			}
 		case fieldValue.Kind() == reflect.Struct:
-			if err := m.unmarshalObj(key, fieldValue.Addr().Interface()); err != nil {
+			if err := p.unmarshalObj(field, fieldValue.Addr().Interface()); err != nil {
 				return err
 			}
 		case fieldValue.Kind() == reflect.Slice:
-			switch fieldValue.Type().Elem().Kind() {
-			case reflect.Struct:
-				if err := m.unmarshalObj(key, fieldValue.Interface()); err != nil {
-					return err
-				}
-			case reflect.Int:
-				err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-					value, err := engine.GetIntSlice(key)
-					if err != nil {
-						return err
-					}
-					fieldValue.Set(reflect.ValueOf(value))
-					return nil
-				})
-				if err != nil {
-					return err
-				}
-			case reflect.Int64:
-				err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-					value, err := engine.GetInt64Slice(key)
-					if err != nil {
-						return err
-					}
-					fieldValue.Set(reflect.ValueOf(value))
-					return nil
-				})
-				if err != nil {
-					return err
-				}
-			case reflect.String:
-				err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-					value, err := engine.GetStringSlice(key)
-					if err != nil {
-						return err
-					}
-					fieldValue.Set(reflect.ValueOf(value))
-					return nil
-				})
-				if err != nil {
-					return err
-				}
-			case reflect.Bool:
-				err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-					value, err := engine.GetBoolSlice(key)
-					if err != nil {
-						return err
-					}
-					fieldValue.Set(reflect.ValueOf(value))
-					return nil
-				})
-				if err != nil {
-					return err
-				}
-			case reflect.Float64:
-				err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-					value, err := engine.GetFloatSlice(key)
-					if err != nil {
-						return err
-					}
-					fieldValue.Set(reflect.ValueOf(value))
-					return nil
-				})
-				if err != nil {
-					return err
-				}
+			var err error
+			if found, err = p.unmarshalStructSlice(field, fieldValue); err != nil {
+				return err
 			}
-		case fieldValue.Kind() == reflect.String:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetString(key)
-				if err != nil {
+			if !found && isRequired {
+				if err := p.fail(field, nil, fmt.Errorf("%w: %s", ErrKeyNotFound, path)); err != nil {
 					return err
 				}
-				fieldValue.SetString(value)
-				return nil
-			})
+			}
+		case fieldValue.Kind() == reflect.Map:
+			var err error
+			found, err = p.unmarshalMap(engines, field, tag, fieldValue)
+			if err == nil && !found && defaultEngine != nil {
+				found, err = p.unmarshalMap([]Engine{defaultEngine}, field, tag, fieldValue)
+			}
 			if err != nil {
 				return err
 			}
-		case fieldValue.Kind() == reflect.Int || fieldValue.Kind() == reflect.Int8 || fieldValue.Kind() == reflect.Int16 || fieldValue.Kind() == reflect.Int32:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetInt(key)
-				if err != nil {
+			if !found && isRequired {
+				if err := p.fail(field, nil, fmt.Errorf("%w: %s", ErrKeyNotFound, path)); err != nil {
 					return err
 				}
-				fieldValue.SetInt(int64(value))
-				return nil
-			})
-			if err != nil {
-				return err
 			}
-		case fieldValue.Kind() == reflect.Int64:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				switch fieldValue.Type().String() {
-				case "time.Duration":
-					value, err := engine.GetDuration(key)
-					if !isRequired && errors.Is(err, ErrKeyNotFound) {
-						return nil
-					} else if err != nil {
-						return err
-					}
-					fieldValue.Set(reflect.ValueOf(value))
-				default:
-					value, err := engine.GetInt64(key)
-					if !isRequired && errors.Is(err, ErrKeyNotFound) {
-						return nil
-					} else if err != nil {
-						return err
-					}
-					fieldValue.SetInt(value)
-				}
-				return nil
-			})
-			if err != nil {
+		}
+
+		if err := rules.errs[f]; err != nil {
+			if err := p.fail(field, nil, fmt.Errorf("%w: %s: %w", ErrInvalidValidateTag, path, err)); err != nil {
 				return err
 			}
-		case fieldValue.Kind() == reflect.Uint || fieldValue.Kind() == reflect.Uint8 || fieldValue.Kind() == reflect.Uint16 || fieldValue.Kind() == reflect.Uint32:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetUint(key)
-				if err != nil {
-					return err
-				}
-				fieldValue.SetUint(uint64(value))
-				return nil
-			})
-			if err != nil {
+		} else if found && len(rules.rules[f]) > 0 {
+			if err := p.validate(field, rules.rules[f], fieldValue); err != nil {
 				return err
 			}
-		case fieldValue.Kind() == reflect.Int64:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetInt64(key)
-				if err != nil {
-					return err
-				}
-				fieldValue.SetInt(value)
-				return nil
-			})
-			if err != nil {
+		}
+	}
+
+	if validator, ok := obj.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			if p.collectAllErrors {
+				return p.fail(ref, nil, err)
+			}
+			if ref.path != "" {
+				return fmt.Errorf("%s: %w", ref.path, err)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unmarshalPtr populates the pointer field v, allocating it only when any engine has its key (or, for pointers to
+// structs, any key nested under it) so nil means "not configured". Pointers that are already set are reused.
+func (p *populator) unmarshalPtr(engines []Engine, defaultEngine Engine, ref fieldRef, tag fieldTag, v reflect.Value) error {
+	elemType := v.Type().Elem()
+	elem := v
+	if elem.IsNil() {
+		elem = reflect.New(elemType)
+	}
+
+	found := false
+	switch {
+	case p.decoders.has(elemType):
+		var err error
+		if found, err = p.readField(engines, defaultEngine, ref, tag, false, elem.Elem()); err != nil {
+			return err
+		}
+	case isStructType(elemType):
+		if p.hasKeys(ref.key, elemType) {
+			if err := p.unmarshalObj(ref, elem.Interface()); err != nil {
 				return err
 			}
-		case fieldValue.Kind() == reflect.Uint64:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetUint64(key)
-				if err != nil {
-					return err
-				}
-				fieldValue.SetUint(value)
-				return nil
-			})
+			found = true
+		}
+	case elemType.Kind() == reflect.Map:
+		var err error
+		found, err = p.unmarshalMap(engines, ref, tag, elem.Elem())
+		if err == nil && !found && defaultEngine != nil {
+			found, err = p.unmarshalMap([]Engine{defaultEngine}, ref, tag, elem.Elem())
+		}
+		if err != nil {
+			return err
+		}
+	case p.decoders.isLeafType(elemType):
+		var err error
+		if found, err = p.readField(engines, defaultEngine, ref, tag, false, elem.Elem()); err != nil {
+			return err
+		}
+	}
+
+	if !found {
+		if tag.required {
+			return p.fail(ref, nil, fmt.Errorf("%w: %s", ErrKeyNotFound, ref.path))
+		}
+		return nil
+	}
+	v.Set(elem)
+	return nil
+}
+
+// unmarshalStructSlice populates the slice of structs (or pointers to structs) v with the elements found under ref.
+// The elements are read from indexed keys, `servers.0.host` being the `host` of the first element of `servers`, and
+// the slice ends at the first index for which no engine has any key. It returns whether any element was found.
+//
+// Each element is validated on its own, when it implements Validator.
+func (p *populator) unmarshalStructSlice(ref fieldRef, v reflect.Value) (bool, error) {
+	elemType := v.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	result := reflect.MakeSlice(v.Type(), 0, 0)
+	for i := 0; ; i++ {
+		elemRef := ref.index(p.keySeparator, i)
+		if !p.hasKeys(elemRef.key, structType) {
+			break
+		}
+		elem := reflect.New(structType)
+		if err := p.unmarshalObj(elemRef, elem.Interface()); err != nil {
+			return false, err
+		}
+		if elemType.Kind() != reflect.Ptr {
+			elem = elem.Elem()
+		}
+		result = reflect.Append(result, elem)
+	}
+	if result.Len() == 0 {
+		return false, nil
+	}
+	v.Set(result)
+	return true, nil
+}
+
+// hasKeys reports whether any engine holds a value for the given key or, for structs, for any of the keys nested
+// under it. Default values are not taken into account.
+func (m *Manager) hasKeys(key string, t reflect.Type) bool {
+	return m.hasNestedKeys(key, t, nil)
+}
+
+// hasNestedKeys is hasKeys for the type t nested in the structs of the stack. The structs that are already in the
+// stack, like the children of a tree, are not walked again: they only have keys when an engine lists a key under
+// key, which the engines without KeyLister cannot tell.
+func (m *Manager) hasNestedKeys(key string, t reflect.Type, stack []reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !isStructType(t) || m.decoders.has(t) {
+		if hasKey(m.plains, key) || hasKey(m.secrets, key) {
+			return true
+		}
+		if t.Kind() == reflect.Map {
+			return hasKeyWithPrefix(m.plains, key+m.keySeparator) || hasKeyWithPrefix(m.secrets, key+m.keySeparator)
+		}
+		if t.Kind() == reflect.Slice && isStructType(derefType(t.Elem())) {
+			return m.hasNestedKeys(key+m.keySeparator+"0", t.Elem(), stack)
+		}
+		return false
+	}
+	if slices.Contains(stack, t) {
+		return hasKeyWithPrefix(m.plains, key+m.keySeparator) || hasKeyWithPrefix(m.secrets, key+m.keySeparator)
+	}
+	stack = append(stack[:len(stack):len(stack)], t)
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := parseFieldTag(t.Field(i))
+		if !ok {
+			continue
+		}
+		if m.hasNestedKeys(key+m.keySeparator+tag.name, t.Field(i).Type, stack) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKey(engines []Engine, key string) bool {
+	for _, engine := range engines {
+		_, err := engine.GetString(key)
+		if err == nil || !(errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrEngineNotLoaded)) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKeyWithPrefix(engines []Engine, prefix string) bool {
+	for _, engine := range engines {
+		lister, ok := engine.(KeyLister)
+		if !ok {
+			continue
+		}
+		for _, k := range lister.Keys() {
+			if strings.HasPrefix(k, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isStructType reports whether t is a struct whose fields are populated from nested keys. Structs read from a single
+// value, like time.Time, the ones implementing encoding.TextUnmarshaler and the ones with a built-in decoder, are not.
+func isStructType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType) &&
+		!builtinDecoders.has(t)
+}
+
+// isLeafType reports whether values of the type t are read from a single key, instead of having their fields,
+// elements or entries spread across nested keys.
+func isLeafType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return !isStructType(t)
+	case reflect.Map:
+		return false
+	case reflect.Slice:
+		return !isStructType(derefType(t.Elem()))
+	}
+	return true
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// readValue reads the value of key from the given engine into v, using the Engine getter that matches the type of v.
+//
+// Types with a decoder, registered in decoders or built in, and types implementing encoding.TextUnmarshaler are read
+// as strings. When the engine holds a value of another type for the key, they are read according to their kind
+// instead.
+func readValue(engine Engine, key string, v reflect.Value, decoders decoderRegistry) error {
+	if decode, ok := decoders.lookup(v.Type()); ok {
+		value, err := engine.GetString(key)
+		if err == nil {
+			decoded, err := decode(value)
 			if err != nil {
-				return err
+				return fmt.Errorf("%w: %s", err, key)
 			}
-		case fieldValue.Kind() == reflect.Float64 || fieldValue.Kind() == reflect.Float32:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetFloat(key)
-				if err != nil {
-					return err
-				}
-				fieldValue.SetFloat(value)
-				return nil
-			})
+			v.Set(decoded)
+			return nil
+		}
+		if !errors.Is(err, ErrTypeMismatch) {
+			return err
+		}
+	}
+
+	if textUnmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		value, err := engine.GetString(key)
+		if err == nil {
+			if err := textUnmarshaler.UnmarshalText([]byte(value)); err != nil {
+				return fmt.Errorf("%w: %s", err, key)
+			}
+			return nil
+		}
+		if !errors.Is(err, ErrTypeMismatch) {
+			return err
+		}
+	}
+
+	if v.Type() == timeType {
+		timeEngine, ok := engine.(TimeGetter)
+		if !ok {
+			return ErrKeyNotFound
+		}
+		value, err := timeEngine.GetTime(key)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		if decode, ok := decoders.lookup(v.Type().Elem()); ok {
+			return readDecodedSlice(engine, key, v, decode)
+		}
+		var (
+			value interface{}
+			err   error
+		)
+		switch elem := v.Type().Elem(); {
+		case elem == durationType:
+			value, err = getDurationSlice(engine, key)
+		case elem.Kind() == reflect.Int, elem.Kind() == reflect.Int8, elem.Kind() == reflect.Int16,
+			elem.Kind() == reflect.Int32:
+			value, err = engine.GetIntSlice(key)
+		case elem.Kind() == reflect.Int64:
+			value, err = engine.GetInt64Slice(key)
+		case elem.Kind() == reflect.Uint, elem.Kind() == reflect.Uint8, elem.Kind() == reflect.Uint16,
+			elem.Kind() == reflect.Uint32:
+			value, err = engine.GetUintSlice(key)
+		case elem.Kind() == reflect.Uint64:
+			value, err = engine.GetUint64Slice(key)
+		case elem.Kind() == reflect.String:
+			value, err = engine.GetStringSlice(key)
+		case elem.Kind() == reflect.Bool:
+			value, err = engine.GetBoolSlice(key)
+		case elem.Kind() == reflect.Float32, elem.Kind() == reflect.Float64:
+			value, err = engine.GetFloatSlice(key)
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return setSlice(key, v, reflect.ValueOf(value))
+	case reflect.String:
+		value, err := engine.GetString(key)
+		if err != nil {
+			return err
+		}
+		v.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		value, err := engine.GetInt(key)
+		if err != nil {
+			return err
+		}
+		return setInt(key, v, int64(value))
+	case reflect.Int64:
+		switch v.Type().String() {
+		case "time.Duration":
+			value, err := engine.GetDuration(key)
 			if err != nil {
 				return err
 			}
-		case fieldValue.Kind() == reflect.Bool:
-			err := readFromEnginesInSequence(engines, key, isRequired, func(engine Engine) error {
-				value, err := engine.GetBool(key)
-				if err != nil {
-					return err
-				}
-				fieldValue.SetBool(value)
-				return nil
-			})
+			v.SetInt(int64(value))
+		default:
+			value, err := engine.GetInt64(key)
 			if err != nil {
 				return err
 			}
+			v.SetInt(value)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		value, err := engine.GetUint(key)
+		if err != nil {
+			return err
 		}
+		return setUint(key, v, uint64(value))
+	case reflect.Uint64:
+		value, err := engine.GetUint64(key)
+		if err != nil {
+			return err
+		}
+		v.SetUint(value)
+	case reflect.Float64, reflect.Float32:
+		value, err := engine.GetFloat(key)
+		if err != nil {
+			return err
+		}
+		return setFloat(key, v, value)
+	case reflect.Bool:
+		value, err := engine.GetBool(key)
+		if err != nil {
+			return err
+		}
+		v.SetBool(value)
 	}
+	return nil
+}
 
-	if validator, ok := obj.(Validator); ok {
-		if err := validator.Validate(); err != nil {
+// getDurationSlice reads the value of key from engine as a list of durations, like `1s,500ms`. The lists of numbers
+// held by engines like YAMLEngine are read as nanoseconds.
+func getDurationSlice(engine Engine, key string) ([]time.Duration, error) {
+	values, err := engine.GetStringSlice(key)
+	if errors.Is(err, ErrTypeMismatch) {
+		numbers, err := engine.GetInt64Slice(key)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]time.Duration, len(numbers))
+		for i, n := range numbers {
+			result[i] = time.Duration(n)
+		}
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := make([]time.Duration, len(values))
+	for i, value := range values {
+		if result[i], err = parseDuration(value); err != nil {
+			return nil, newErrInvalidValue(key, err)
+		}
+	}
+	return result, nil
+}
+
+// readDecodedSlice reads the value of key from engine as a list of strings into the slice v, decoding each of them.
+func readDecodedSlice(engine Engine, key string, v reflect.Value, decode decodeFunc) error {
+	values, err := engine.GetStringSlice(key)
+	if err != nil {
+		return err
+	}
+	result := reflect.MakeSlice(v.Type(), len(values), len(values))
+	for i, value := range values {
+		decoded, err := decode(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, key)
+		}
+		result.Index(i).Set(decoded)
+	}
+	v.Set(result)
+	return nil
+}
+
+// setSlice sets the slice v to the elements of value, a slice returned by the Engine getters, checking that each of
+// them fits in the element type of v.
+func setSlice(key string, v, value reflect.Value) error {
+	if value.Type().ConvertibleTo(v.Type()) {
+		v.Set(value.Convert(v.Type()))
+		return nil
+	}
+	result := reflect.MakeSlice(v.Type(), value.Len(), value.Len())
+	for i := 0; i < value.Len(); i++ {
+		var err error
+		switch elem := value.Index(i); elem.Kind() {
+		case reflect.Int, reflect.Int64:
+			err = setInt(key, result.Index(i), elem.Int())
+		case reflect.Uint, reflect.Uint64:
+			err = setUint(key, result.Index(i), elem.Uint())
+		case reflect.Float64:
+			err = setFloat(key, result.Index(i), elem.Float())
+		default:
+			result.Index(i).Set(elem.Convert(v.Type().Elem()))
+		}
+		if err != nil {
 			return err
 		}
 	}
+	v.Set(result)
+	return nil
+}
 
+// setInt sets v to n, failing with ErrValueOutOfRange when n does not fit in the type of v.
+func setInt(key string, v reflect.Value, n int64) error {
+	if v.OverflowInt(n) {
+		return newErrValueOutOfRange(key, n, v.Type())
+	}
+	v.SetInt(n)
 	return nil
 }
 
-func readFromEnginesInSequence(engines []Engine, key string, isRequired bool, f func(engine Engine) error) error {
-	var err error
+// setUint sets v to n, failing with ErrValueOutOfRange when n does not fit in the type of v.
+func setUint(key string, v reflect.Value, n uint64) error {
+	if v.OverflowUint(n) {
+		return newErrValueOutOfRange(key, n, v.Type())
+	}
+	v.SetUint(n)
+	return nil
+}
+
+// setFloat sets v to f, failing with ErrValueOutOfRange when f does not fit in the type of v. Values that fit lose
+// the precision the type cannot hold.
+func setFloat(key string, v reflect.Value, f float64) error {
+	if v.OverflowFloat(f) {
+		return newErrValueOutOfRange(key, f, v.Type())
+	}
+	v.SetFloat(f)
+	return nil
+}
+
+// unmarshalMap populates the map v with the entries found under key. It returns whether any entry was found.
+//
+// Engines implementing KeyLister have their entries discovered from the keys nested under key, so `labels.team` is
+// the `team` entry of the `labels` map. The other engines, and the KeyLister engines without nested keys, can provide
+// all the entries in a single string value formatted as `k1=v1,k2=v2`. When the same entry is provided by multiple
+// engines, the first one wins.
+//
+// Struct values are populated from their nested keys by all the engines of the Manager, so their entries can only be
+// discovered by KeyLister engines.
+func (p *populator) unmarshalMap(engines []Engine, ref fieldRef, tag fieldTag, v reflect.Value) (bool, error) {
+	t := v.Type()
+	if t.Key().Kind() != reflect.String {
+		return false, nil
+	}
+
+	key := ref.key
+	prefix := key + p.keySeparator
+	sources := make([]Engine, 0, len(engines))
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	addName := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
 	for _, engine := range engines {
+		// The engines holding nested maps give the names as they are: the keys they list are flattened, so the
+		// names holding the key separator would be cut.
+		if reader, ok := engine.(mapEntriesReader); ok {
+			if entries, ok := reader.entries(key, p.keySeparator); ok {
+				data := make(map[string]interface{}, len(entries))
+				for name, value := range entries {
+					addName(name)
+					data[prefix+name] = value
+				}
+				sources = append(sources, &mapEntriesEngine{NewMapEngine(data), engine})
+				continue
+			}
+		}
+
+		if lister, ok := engine.(KeyLister); ok {
+			found := false
+			for _, k := range lister.Keys() {
+				if rest, ok := strings.CutPrefix(k, prefix); ok && rest != "" {
+					name, _, _ := strings.Cut(rest, p.keySeparator)
+					addName(name)
+					found = true
+				}
+			}
+			if found {
+				sources = append(sources, engine)
+				continue
+			}
+		}
+
+		raw, err := engine.GetString(key)
+		switch {
+		case errors.Is(err, ErrKeyNotFound), errors.Is(err, ErrTypeMismatch), errors.Is(err, ErrEngineNotLoaded):
+			continue
+		case err != nil:
+			return false, err
+		}
+		entries, err := parseMapEntries(ref.path, raw)
+		if err != nil {
+			if err := p.failRead(ref, engine, err); err != nil {
+				return false, err
+			}
+			continue
+		}
+		data := make(map[string]string, len(entries))
+		for name, value := range entries {
+			addName(name)
+			data[prefix+name] = value
+		}
+		source := newStringMapEngine(data, nil)
+		source.source = describeSource(engine, key)
+		sources = append(sources, source)
+	}
+
+	if len(names) == 0 {
+		return false, nil
+	}
+
+	result := reflect.MakeMapWithSize(t, len(names))
+	for _, name := range names {
+		elemRef := ref.entry(p.keySeparator, name)
+		elem := reflect.New(t.Elem()).Elem()
+		if p.decoders.isLeafType(t.Elem()) {
+			var (
+				winner Engine
+				missed []Engine
+			)
+			engine, err := readFromEnginesInSequence(sources, elemRef.path, true, func(engine Engine) error {
+				err := p.read(engine, elemRef.key, tag, elem)
+				if err == nil {
+					winner = engine
+				} else if errors.Is(err, ErrKeyNotFound) {
+					missed = append(missed, engine)
+				}
+				return err
+			})
+			if winner != nil {
+				p.record(elemRef, tag, winner, missed)
+			}
+			if errors.Is(err, ErrKeyNotFound) {
+				// The name is only a prefix of deeper keys, which cannot be read into a leaf value.
+				continue
+			} else if err != nil {
+				if err := p.failRead(elemRef, engine, err); err != nil {
+					return false, err
+				}
+				continue
+			}
+		} else if elem.Kind() == reflect.Struct {
+			if err := p.unmarshalObj(elemRef, elem.Addr().Interface()); err != nil {
+				return false, err
+			}
+		} else {
+			continue
+		}
+		result.SetMapIndex(reflect.ValueOf(name).Convert(t.Key()), elem)
+	}
+	v.Set(result)
+	return true, nil
+}
+
+// mapEntriesReader is implemented by the engines holding nested maps, like MapEngine, to read the entries of the map
+// at key with their names whole.
+type mapEntriesReader interface {
+	entries(key, separator string) (map[string]interface{}, bool)
+}
+
+// mapEntriesEngine reads the entries returned by a mapEntriesReader, described as the engine they come from.
+type mapEntriesEngine struct {
+	*MapEngine
+	from Engine
+}
+
+func (engine *mapEntriesEngine) DescribeSource(key string) string {
+	return describeSource(engine.from, key)
+}
+
+// parseMapEntries parses a map written as `k1=v1,k2=v2`.
+func parseMapEntries(key, raw string) (map[string]string, error) {
+	entries := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return entries, nil
+	}
+	for _, entry := range strings.Split(raw, ",") {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %s: %q", ErrInvalidMapEntry, key, entry)
+		}
+		entries[name] = strings.TrimSpace(value)
+	}
+	return entries, nil
+}
+
+// fieldTag holds the options set on the `config`, `default`, `desc` and `validate` tags of a struct field.
+type fieldTag struct {
+	name          string
+	required      bool
+	secret        bool
+	defaultValue  string
+	hasDefault    bool
+	noInterpolate bool
+	interpolate   bool
+	description   string
+	validate      string
+	// layout and tz are the `layout=` and `tz=` options of time.Time fields.
+	layout string
+	tz     string
+	// err is set when the `config` tag has a `layout=` option cut at a comma.
+	err error
+}
+
+// interpolates reports whether the references in the values of the field are expanded. Secrets, like passwords, are
+// read as they are unless the field opts in with the `interpolate` option, as a `${` in them is rarely a reference.
+func (tag fieldTag) interpolates() bool {
+	return !tag.noInterpolate && (!tag.secret || tag.interpolate)
+}
+
+// parseFieldTag parses the `config`, `default`, `desc` and `validate` tags of the given field. It returns false when
+// the field is not tagged or is explicitly skipped with `config:"-"`. The unknown options of the `config` tag are
+// ignored, but for the layouts of time.Time fields cut at a comma, reported in the err of the fieldTag for the callers
+// to fail on them.
+func parseFieldTag(field reflect.StructField) (fieldTag, bool) {
+	tokens := strings.Split(field.Tag.Get("config"), ",")
+	tag := fieldTag{name: tokens[0]}
+	if tag.name == "-" || tag.name == "" {
+		return tag, false
+	}
+	tag.defaultValue, tag.hasDefault = field.Tag.Lookup("default")
+	tag.description = field.Tag.Get("desc")
+	tag.validate = field.Tag.Get("validate")
+	afterLayout := false
+	for _, tok := range tokens[1:] {
+		// The unknown options are ignored, but for the rest of a layout of a time.Time field cut at one of its commas.
+		cutLayout := afterLayout
+		afterLayout = false
+		switch tok {
+		case "required":
+			tag.required = true
+		case "secret":
+			tag.secret = true
+		case "nointerpolate":
+			tag.noInterpolate = true
+		case "interpolate":
+			tag.interpolate = true
+		default:
+			if layout, ok := strings.CutPrefix(tok, "layout="); ok {
+				tag.layout = layout
+				if named, ok := namedTimeLayouts[layout]; ok {
+					tag.layout = named
+				}
+				afterLayout = derefType(field.Type) == timeType
+			} else if tz, ok := strings.CutPrefix(tok, "tz="); ok {
+				tag.tz = tz
+			} else if cutLayout && !isTagOption(tok) && tag.err == nil {
+				tag.err = fmt.Errorf("layout %q is cut at a comma, before %q: give it by name", tag.layout, tok)
+			}
+		}
+	}
+	return tag, true
+}
+
+// isTagOption reports whether tok reads as an option of a struct tag, like `omitempty` or `key=value`, rather than a
+// part of a time layout.
+func isTagOption(tok string) bool {
+	return tok != "" && !strings.ContainsFunc(tok, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '='
+	})
+}
+
+// readFromEnginesInSequence calls f for each engine until one of them does not return ErrKeyNotFound. When none of
+// the engines has the key and isRequired is set, an ErrKeyNotFound naming the given path is returned. The engine
+// returned is the last one tried.
+func readFromEnginesInSequence(engines []Engine, path string, isRequired bool, f func(engine Engine) error) (Engine, error) {
+	var (
+		engine Engine
+		err    error
+	)
+	for _, engine = range engines {
 		err = f(engine)
 		if errors.Is(err, ErrKeyNotFound) {
 			continue
 		} else if err != nil {
-			return err
+			return engine, err
 		}
-		return nil
+		return engine, nil
 	}
 
 	if isRequired && errors.Is(err, ErrKeyNotFound) {
-		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+		return engine, fmt.Errorf("%w: %s", ErrKeyNotFound, path)
 	}
 
-	return nil
+	return engine, nil
 }
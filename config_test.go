@@ -88,6 +88,137 @@ func TestWithLoadOptionsEnv(t *testing.T) {
 		})
 	})
 
+	t.Run("when plain is overridden to jsonfile, reads from the given file", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"PASSWORD": "env-pass",
+		}, func() {
+			os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["jsonfile:testdata/config_simple.json"],"secrets":["env"]}`)
+			defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+			m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+			var cfg MyTestConfig
+			require.NoError(t, m.Populate(&cfg))
+			assert.Equal(t, "from-json", cfg.DSN)
+			assert.Equal(t, "env-pass", cfg.Password)
+		})
+	})
+
+	t.Run("when plain is overridden to jsonfileenv, reads file path from env var", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"PASSWORD":         "env-pass",
+			"JSON_CONFIG_PATH": "testdata/config_simple.json",
+		}, func() {
+			os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["jsonfileenv:JSON_CONFIG_PATH"],"secrets":["env"]}`)
+			defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+			m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+			var cfg MyTestConfig
+			require.NoError(t, m.Populate(&cfg))
+			assert.Equal(t, "from-json", cfg.DSN)
+		})
+	})
+
+	t.Run("when jsonfileenv references an unset env var, Populate returns error", func(t *testing.T) {
+		os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["jsonfileenv:MISSING_JSON_VAR"]}`)
+		defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+		m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+		var cfg MyTestConfig
+		err := m.Populate(&cfg)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "MISSING_JSON_VAR")
+	})
+
+	t.Run("when plain is overridden to tomlfile, reads from the given file", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"PASSWORD": "env-pass",
+		}, func() {
+			os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["tomlfile:testdata/config_simple.toml"],"secrets":["env"]}`)
+			defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+			m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+			var cfg MyTestConfig
+			require.NoError(t, m.Populate(&cfg))
+			assert.Equal(t, "from-toml", cfg.DSN)
+			assert.Equal(t, "env-pass", cfg.Password)
+		})
+	})
+
+	t.Run("when plain is overridden to tomlfileenv, reads file path from env var", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"PASSWORD":         "env-pass",
+			"TOML_CONFIG_PATH": "testdata/config_simple.toml",
+		}, func() {
+			os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["tomlfileenv:TOML_CONFIG_PATH"],"secrets":["env"]}`)
+			defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+			m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+			var cfg MyTestConfig
+			require.NoError(t, m.Populate(&cfg))
+			assert.Equal(t, "from-toml", cfg.DSN)
+		})
+	})
+
+	t.Run("when plain is overridden to dotenvfile, reads from the given file", func(t *testing.T) {
+		os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["dotenvfile:testdata/config_simple.env"],"secrets":["dotenvfile:testdata/config_simple.env"]}`)
+		defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+		m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+		var cfg MyTestConfig
+		require.NoError(t, m.Populate(&cfg))
+		assert.Equal(t, "from-dotenv", cfg.DSN)
+		assert.Equal(t, "dotenv-pass", cfg.Password)
+	})
+
+	t.Run("when plain is overridden to inifile, reads from the given file", func(t *testing.T) {
+		os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["inifile:testdata/config_simple.ini"],"secrets":["inifile:testdata/config_simple.ini"]}`)
+		defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+		m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+		var cfg MyTestConfig
+		require.NoError(t, m.Populate(&cfg))
+		assert.Equal(t, "from-ini", cfg.DSN)
+		assert.Equal(t, "ini-pass", cfg.Password)
+	})
+
+	t.Run("when plain is overridden to propertiesfileenv, reads file path from env var", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"PROPERTIES_CONFIG_PATH": "testdata/config_simple.properties",
+		}, func() {
+			os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["propertiesfileenv:PROPERTIES_CONFIG_PATH"],"secrets":["propertiesfile:testdata/config_simple.properties"]}`)
+			defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+			m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+			var cfg MyTestConfig
+			require.NoError(t, m.Populate(&cfg))
+			assert.Equal(t, "from-properties", cfg.DSN)
+			assert.Equal(t, "properties-pass", cfg.Password)
+		})
+	})
+
+	t.Run("when secrets are overridden to dir, reads from the files of the directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, dir+"/password", "dir-pass\n")
+
+		os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["yamlfile:testdata/config_simple.yaml"],"secrets":["dir:`+dir+`"]}`)
+		defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
+
+		m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+		var cfg MyTestConfig
+		require.NoError(t, m.Populate(&cfg))
+		assert.Equal(t, "from-yaml", cfg.DSN)
+		assert.Equal(t, "dir-pass", cfg.Password)
+	})
+
 	t.Run("when yamlfileenv references an unset env var, Populate returns error", func(t *testing.T) {
 		os.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["yamlfileenv:MISSING_VAR"]}`)
 		defer os.Unsetenv("CONFIG_LOAD_OPTIONS_TEST")
@@ -154,6 +285,80 @@ type MyTestConfigAllTypes struct {
 	KeyFloatSlice  []float64 `config:"key_float_slice"`
 }
 
+type MyTestConfigWithTime struct {
+	StartsAt time.Time `config:"starts_at,required"`
+	EndsAt   time.Time `config:"ends_at"`
+}
+
+type MyTestConfigWithDefaults struct {
+	Host     string        `config:"host" default:"localhost"`
+	Port     uint16        `config:"port" default:"8080"`
+	Workers  int           `config:"workers" default:"4"`
+	Ratio    float64       `config:"ratio" default:"0.5"`
+	Debug    bool          `config:"debug" default:"true"`
+	Tags     []string      `config:"tags" default:"a,b"`
+	Weights  []int         `config:"weights" default:"1,2,3"`
+	Timeout  time.Duration `config:"timeout" default:"30s"`
+	MaxBody  bs.ByteSize   `config:"max_body" default:"1MB"`
+	StartsAt time.Time     `config:"starts_at" default:"2024-03-01T10:00:00Z"`
+	Name     string        `config:"name,required" default:"app"`
+	Empty    string        `config:"empty"`
+}
+
+type MyTestUpstream struct {
+	Host    string        `config:"host,required"`
+	Port    int           `config:"port" default:"80"`
+	Timeout time.Duration `config:"timeout"`
+}
+
+type MyTestConfigWithMaps struct {
+	Labels    map[string]string         `config:"labels"`
+	Limits    map[string]int            `config:"limits"`
+	Timeouts  map[string]time.Duration  `config:"timeouts"`
+	Upstreams map[string]MyTestUpstream `config:"upstreams"`
+	Defaults  map[string]string         `config:"defaults" default:"a=1,b=2"`
+}
+
+type MyTestServer struct {
+	Host string `config:"host,required"`
+	Port int    `config:"port,required"`
+}
+
+func (s MyTestServer) Validate() error {
+	if s.Port <= 0 {
+		return errMustBePositive
+	}
+	return nil
+}
+
+type MyTestConfigWithSlices struct {
+	Servers  []MyTestServer  `config:"servers"`
+	Replicas []*MyTestServer `config:"replicas"`
+}
+
+type MyTestConfigWithPointers struct {
+	Name     *string                         `config:"name"`
+	Port     *int                            `config:"port"`
+	Debug    *bool                           `config:"debug"`
+	Timeout  *time.Duration                  `config:"timeout"`
+	Size     *bs.ByteSize                    `config:"size"`
+	Database *MyTestWithNestedConfigDatabase `config:"database"`
+	Server   *MyTestServer                   `config:"server"`
+	Workers  *int                            `config:"workers" default:"4"`
+}
+
+type MyTestLinkedNode struct {
+	Name string            `config:"name"`
+	Next *MyTestLinkedNode `config:"next"`
+}
+
+type MyTestConfigWithErrors struct {
+	Name    string         `config:"name,required"`
+	Port    int            `config:"port"`
+	Servers []MyTestServer `config:"servers"`
+	Limits  map[string]int `config:"limits"`
+}
+
 type MyTestConfigWithValidation struct {
 	N int `config:"n"`
 }
@@ -263,6 +468,490 @@ func TestManager_Populate(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("success with time fields", func(t *testing.T) {
+		manager := NewManager()
+
+		tomlEngine := NewTOMLEngine(NewBytesLoader([]byte(`starts_at = 2024-03-01T10:00:00Z
+ends_at = 2024-03-02T10:00:00
+`)))
+		manager.AddPlainEngine(tomlEngine)
+
+		var cfg MyTestConfigWithTime
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.True(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC).Equal(cfg.StartsAt))
+		assert.Equal(t, time.Date(2024, 3, 2, 10, 0, 0, 0, time.Local), cfg.EndsAt)
+	})
+
+	t.Run("success with default values", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+
+		var cfg MyTestConfigWithDefaults
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.Equal(t, MyTestConfigWithDefaults{
+			Host:     "localhost",
+			Port:     8080,
+			Workers:  4,
+			Ratio:    0.5,
+			Debug:    true,
+			Tags:     []string{"a", "b"},
+			Weights:  []int{1, 2, 3},
+			Timeout:  30 * time.Second,
+			MaxBody:  bs.MB,
+			StartsAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+			Name:     "app",
+		}, cfg)
+	})
+
+	t.Run("default values are only used when no engine has the key", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(
+			NewMapEngine(map[string]interface{}{
+				"host": "from-first",
+			}),
+			NewMapEngine(map[string]interface{}{
+				"timeout": time.Second,
+				"debug":   false,
+			}),
+		)
+
+		var cfg MyTestConfigWithDefaults
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "from-first", cfg.Host)
+		assert.Equal(t, time.Second, cfg.Timeout)
+		assert.False(t, cfg.Debug)
+		assert.Equal(t, uint16(8080), cfg.Port)
+	})
+
+	t.Run("fail when the default value cannot be parsed", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+
+		var cfg struct {
+			Port int `config:"port" default:"not a number"`
+		}
+		err := manager.Populate(&cfg)
+		require.Error(t, err)
+	})
+
+	t.Run("success with slices of sized numbers and durations", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`levels: [-1, 0, 1]
+ports: [80, 443]
+sizes: [1, 2]
+ratios: [0.5, 1]
+backoff: [1s, 500ms]
+`))))
+
+		var cfg struct {
+			Levels  []int8          `config:"levels"`
+			Ports   []uint16        `config:"ports"`
+			Sizes   []uint64        `config:"sizes"`
+			Ratios  []float32       `config:"ratios"`
+			Backoff []time.Duration `config:"backoff"`
+			Retries []uint32        `config:"retries" default:"1,2,4"`
+		}
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.Equal(t, []int8{-1, 0, 1}, cfg.Levels)
+		assert.Equal(t, []uint16{80, 443}, cfg.Ports)
+		assert.Equal(t, []uint64{1, 2}, cfg.Sizes)
+		assert.Equal(t, []float32{0.5, 1}, cfg.Ratios)
+		assert.Equal(t, []time.Duration{time.Second, 500 * time.Millisecond}, cfg.Backoff)
+		assert.Equal(t, []uint32{1, 2, 4}, cfg.Retries)
+	})
+
+	t.Run("success with slices of durations held as numbers", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"d": []interface{}{1000, 2000}}))
+
+		var cfg struct {
+			D []time.Duration `config:"d"`
+		}
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, []time.Duration{1000, 2000}, cfg.D)
+	})
+
+	t.Run("fail naming the key of an invalid duration in a slice", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"backoff": []interface{}{"1s", "soon"}}))
+
+		var cfg struct {
+			Backoff []time.Duration `config:"backoff"`
+		}
+		assert.EqualError(t, manager.Populate(&cfg), `backoff: time: invalid duration "soon"`)
+	})
+
+	t.Run("fail when a number does not fit in the field", func(t *testing.T) {
+		tests := []struct {
+			name string
+			cfg  interface{}
+			data map[string]interface{}
+		}{
+			{"int8", &struct {
+				V int8 `config:"v"`
+			}{}, map[string]interface{}{"v": 300}},
+			{"uint16", &struct {
+				V uint16 `config:"v"`
+			}{}, map[string]interface{}{"v": 70000}},
+			{"float32", &struct {
+				V float32 `config:"v"`
+			}{}, map[string]interface{}{"v": 1e39}},
+			{"int16 slice", &struct {
+				V []int16 `config:"v"`
+			}{}, map[string]interface{}{"v": []interface{}{1, 40000}}},
+			{"uint8 default", &struct {
+				V uint8 `config:"v" default:"256"`
+			}{}, map[string]interface{}{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				manager := NewManager()
+				manager.AddPlainEngine(NewMapEngine(tt.data))
+				err := manager.Populate(tt.cfg)
+				require.ErrorIs(t, err, ErrValueOutOfRange)
+				assert.ErrorContains(t, err, "v: ")
+			})
+		}
+	})
+
+	t.Run("success with maps", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`labels:
+  team: core
+  env: prod
+limits:
+  cpu: 2
+  memory: 512
+timeouts:
+  read: 1s
+  write: 2m
+upstreams:
+  billing:
+    host: billing.local
+    port: 8080
+  search:
+    host: search.local
+    timeout: 5s
+`))))
+
+		var cfg MyTestConfigWithMaps
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"team": "core", "env": "prod"}, cfg.Labels)
+		assert.Equal(t, map[string]int{"cpu": 2, "memory": 512}, cfg.Limits)
+		assert.Equal(t, map[string]time.Duration{"read": time.Second, "write": 2 * time.Minute}, cfg.Timeouts)
+		assert.Equal(t, map[string]MyTestUpstream{
+			"billing": {Host: "billing.local", Port: 8080},
+			"search":  {Host: "search.local", Port: 80, Timeout: 5 * time.Second},
+		}, cfg.Upstreams)
+		assert.Equal(t, map[string]string{"a": "1", "b": "2"}, cfg.Defaults)
+	})
+
+	t.Run("success with map keys holding the key separator", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`labels:
+  app.kubernetes.io/name: web
+  team: core
+limits:
+  billing.svc: 1
+upstreams:
+  billing.svc:
+    host: billing.local
+servers:
+  - host: a
+    port: 1
+    labels:
+      app.kubernetes.io/part-of: shop
+`))))
+
+		var cfg struct {
+			Labels    map[string]string         `config:"labels"`
+			Limits    map[string]int            `config:"limits"`
+			Upstreams map[string]MyTestUpstream `config:"upstreams"`
+			Servers   []struct {
+				Labels map[string]string `config:"labels"`
+			} `config:"servers"`
+		}
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, map[string]string{"app.kubernetes.io/name": "web", "team": "core"}, cfg.Labels)
+		assert.Equal(t, map[string]int{"billing.svc": 1}, cfg.Limits)
+		assert.Equal(t, map[string]MyTestUpstream{"billing.svc": {Host: "billing.local", Port: 80}}, cfg.Upstreams)
+		require.Len(t, cfg.Servers, 1)
+		assert.Equal(t, map[string]string{"app.kubernetes.io/part-of": "shop"}, cfg.Servers[0].Labels)
+	})
+
+	t.Run("success with maps from env vars", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"LABELS":   "team=core, env = prod",
+			"LIMITS":   "cpu=2",
+			"TIMEOUTS": "",
+			"DEFAULTS": "c=3",
+		}, func() {
+			manager := NewManager()
+			envEngine := NewEnvEngine()
+			manager.AddPlainEngine(&envEngine)
+
+			var cfg MyTestConfigWithMaps
+			err := manager.Populate(&cfg)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"team": "core", "env": "prod"}, cfg.Labels)
+			assert.Equal(t, map[string]int{"cpu": 2}, cfg.Limits)
+			assert.Nil(t, cfg.Timeouts)
+			assert.Equal(t, map[string]string{"c": "3"}, cfg.Defaults)
+		})
+	})
+
+	t.Run("map entries are merged across engines, the first engine winning", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"LABELS": "team=from-env",
+		}, func() {
+			manager := NewManager()
+			envEngine := NewEnvEngine()
+			manager.AddPlainEngine(&envEngine, NewMapEngine(map[string]interface{}{
+				"labels": map[string]interface{}{
+					"team": "from-map",
+					"env":  "prod",
+				},
+			}))
+
+			var cfg MyTestConfigWithMaps
+			err := manager.Populate(&cfg)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"team": "from-env", "env": "prod"}, cfg.Labels)
+		})
+	})
+
+	t.Run("fail when a map entry is invalid", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"LABELS": "team",
+		}, func() {
+			manager := NewManager()
+			envEngine := NewEnvEngine()
+			manager.AddPlainEngine(&envEngine)
+
+			var cfg MyTestConfigWithMaps
+			err := manager.Populate(&cfg)
+			require.ErrorIs(t, err, ErrInvalidMapEntry)
+		})
+	})
+
+	t.Run("fail when a required map is not provided", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+
+		var cfg struct {
+			Labels map[string]string `config:"labels,required"`
+		}
+		err := manager.Populate(&cfg)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("success with slices of structs", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`servers:
+  - host: a
+    port: 1
+  - host: b
+    port: 2
+replicas:
+  - host: c
+    port: 3
+`))))
+
+		var cfg MyTestConfigWithSlices
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.Equal(t, []MyTestServer{{Host: "a", Port: 1}, {Host: "b", Port: 2}}, cfg.Servers)
+		assert.Equal(t, []*MyTestServer{{Host: "c", Port: 3}}, cfg.Replicas)
+	})
+
+	t.Run("success with slices of structs from indexed env vars", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"SERVERS_0_HOST":  "a",
+			"SERVERS_0_PORT":  "1",
+			"SERVERS_1_HOST":  "b",
+			"SERVERS_1_PORT":  "2",
+			"REPLICAS_0_HOST": "c",
+			"REPLICAS_0_PORT": "3",
+		}, func() {
+			manager := NewManager()
+			envEngine := NewEnvEngine()
+			manager.AddPlainEngine(&envEngine)
+
+			var cfg MyTestConfigWithSlices
+			err := manager.Populate(&cfg)
+			require.NoError(t, err)
+			assert.Equal(t, []MyTestServer{{Host: "a", Port: 1}, {Host: "b", Port: 2}}, cfg.Servers)
+			assert.Equal(t, []*MyTestServer{{Host: "c", Port: 3}}, cfg.Replicas)
+		})
+	})
+
+	t.Run("fail naming the index of the invalid element", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`servers:
+  - host: a
+    port: 1
+  - host: b
+`))))
+
+		var cfg MyTestConfigWithSlices
+		err := manager.Populate(&cfg)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+		assert.Contains(t, err.Error(), "servers[1].port")
+	})
+
+	t.Run("fail validating each element", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`servers:
+  - host: a
+    port: 1
+  - host: b
+    port: -1
+`))))
+
+		var cfg MyTestConfigWithSlices
+		err := manager.Populate(&cfg)
+		require.ErrorIs(t, err, errMustBePositive)
+		assert.Contains(t, err.Error(), "servers[1]")
+	})
+
+	t.Run("fail when a required slice of structs is not provided", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+
+		var cfg struct {
+			Servers []MyTestServer `config:"servers,required"`
+		}
+		err := manager.Populate(&cfg)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("success with recursive slices of structs", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`root:
+  name: a
+  children:
+    - name: b
+      children:
+        - name: c
+    - name: d
+`))))
+
+		var cfg struct {
+			Root MyTestRecursiveNode `config:"root"`
+		}
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.Equal(t, MyTestRecursiveNode{Name: "a", Children: []MyTestRecursiveNode{
+			{Name: "b", Children: []MyTestRecursiveNode{{Name: "c"}}},
+			{Name: "d"},
+		}}, cfg.Root)
+	})
+
+	t.Run("success with pointers", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+			"name":    "app",
+			"port":    0,
+			"debug":   false,
+			"timeout": "0s",
+			"size":    "1KB",
+			"database": map[string]interface{}{
+				"dsn": wantDSN,
+			},
+			"server": map[string]interface{}{
+				"host": "a",
+				"port": 1,
+			},
+		}))
+
+		var cfg MyTestConfigWithPointers
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		require.NotNil(t, cfg.Name)
+		assert.Equal(t, "app", *cfg.Name)
+		require.NotNil(t, cfg.Port)
+		assert.Equal(t, 0, *cfg.Port)
+		require.NotNil(t, cfg.Debug)
+		assert.False(t, *cfg.Debug)
+		require.NotNil(t, cfg.Timeout)
+		assert.Zero(t, *cfg.Timeout)
+		require.NotNil(t, cfg.Size)
+		assert.Equal(t, bs.KB, *cfg.Size)
+		require.NotNil(t, cfg.Database)
+		assert.Equal(t, wantDSN, cfg.Database.DSN)
+		require.NotNil(t, cfg.Server)
+		assert.Equal(t, MyTestServer{Host: "a", Port: 1}, *cfg.Server)
+		require.NotNil(t, cfg.Workers)
+		assert.Equal(t, 4, *cfg.Workers)
+	})
+
+	t.Run("pointers are left nil when not configured", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+
+		var cfg MyTestConfigWithPointers
+		err := manager.Populate(&cfg)
+		require.NoError(t, err)
+		assert.Nil(t, cfg.Name)
+		assert.Nil(t, cfg.Port)
+		assert.Nil(t, cfg.Debug)
+		assert.Nil(t, cfg.Timeout)
+		assert.Nil(t, cfg.Size)
+		assert.Nil(t, cfg.Database)
+		assert.Nil(t, cfg.Server)
+		require.NotNil(t, cfg.Workers, "pointers with default values are always allocated")
+		assert.Equal(t, 4, *cfg.Workers)
+	})
+
+	t.Run("fail validating pointers to structs", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+			"server": map[string]interface{}{
+				"host": "a",
+				"port": -1,
+			},
+		}))
+
+		var cfg MyTestConfigWithPointers
+		err := manager.Populate(&cfg)
+		require.ErrorIs(t, err, errMustBePositive)
+	})
+
+	t.Run("success with recursive pointers to structs", func(t *testing.T) {
+		withEnvironment(map[string]string{
+			"HEAD_NAME":           "a",
+			"HEAD_NEXT_NAME":      "b",
+			"HEAD_NEXT_NEXT_NAME": "c",
+		}, func() {
+			manager := NewManager()
+			envEngine := NewEnvEngine()
+			manager.AddPlainEngine(&envEngine)
+
+			var cfg struct {
+				Head *MyTestLinkedNode `config:"head"`
+			}
+			err := manager.Populate(&cfg)
+			require.NoError(t, err)
+			assert.Equal(t, &MyTestLinkedNode{Name: "a", Next: &MyTestLinkedNode{Name: "b", Next: &MyTestLinkedNode{Name: "c"}}}, cfg.Head)
+		})
+	})
+
+	t.Run("fail when a required pointer is not provided", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+
+		var cfg struct {
+			Port *int `config:"port,required"`
+		}
+		err := manager.Populate(&cfg)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
 	t.Run("success with nested", func(t *testing.T) {
 		manager := NewManager()
 
@@ -307,6 +996,30 @@ func TestManager_Populate(t *testing.T) {
 		assert.Zero(t, cfg.Timeout)
 	})
 
+	t.Run("missing non required durations and int64 fall through to the next engines and fields", func(t *testing.T) {
+		var cfg struct {
+			Timeout time.Duration `config:"timeout"`
+			Retries int64         `config:"retries"`
+			Backoff time.Duration `config:"backoff"`
+			Host    string        `config:"host"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(
+			NewMapEngine(map[string]interface{}{}),
+			NewMapEngine(map[string]interface{}{
+				"timeout": "5s",
+				"retries": 3,
+				"host":    "localhost",
+			}),
+		)
+
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, 5*time.Second, cfg.Timeout)
+		assert.Equal(t, int64(3), cfg.Retries)
+		assert.Zero(t, cfg.Backoff)
+		assert.Equal(t, "localhost", cfg.Host)
+	})
+
 	t.Run("WHEN reading from multiple engines", func(t *testing.T) {
 		t.Run("WHEN key is present on both engines", func(t *testing.T) {
 			t.Run("should return the value from the second", func(t *testing.T) {
@@ -397,4 +1110,99 @@ func TestManager_Populate(t *testing.T) {
 			require.ErrorIs(t, err, errMustBePositive)
 		})
 	})
+	t.Run("collecting all errors", func(t *testing.T) {
+		engine := NewMapEngine(map[string]interface{}{
+			"port": "not a number",
+			"servers": []interface{}{
+				map[string]interface{}{"port": 8080},
+				map[string]interface{}{"host": "b.example.com", "port": -1},
+			},
+			"limits": map[string]interface{}{"cpu": 2, "memory": "lots"},
+		})
+
+		t.Run("should report all the failed fields", func(t *testing.T) {
+			manager := NewManager(WithCollectAllErrors())
+			manager.AddPlainEngine(engine)
+
+			var cfg MyTestConfigWithErrors
+			err := manager.Populate(&cfg)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrKeyNotFound)
+			require.ErrorIs(t, err, ErrTypeMismatch)
+			require.ErrorIs(t, err, errMustBePositive)
+
+			var fieldErrs FieldErrors
+			require.ErrorAs(t, err, &fieldErrs)
+			require.Len(t, fieldErrs, 5)
+
+			assert.Equal(t, "Name", fieldErrs[0].Path)
+			assert.Equal(t, "name", fieldErrs[0].Key)
+			assert.Equal(t, engine, fieldErrs[0].Engine)
+			assert.ErrorIs(t, fieldErrs[0], ErrKeyNotFound)
+
+			assert.Equal(t, "Port", fieldErrs[1].Path)
+			assert.Equal(t, "port", fieldErrs[1].Key)
+			assert.Equal(t, engine, fieldErrs[1].Engine)
+			assert.ErrorIs(t, fieldErrs[1], ErrTypeMismatch)
+
+			assert.Equal(t, "Servers[0].Host", fieldErrs[2].Path)
+			assert.Equal(t, "servers.0.host", fieldErrs[2].Key)
+			assert.ErrorIs(t, fieldErrs[2], ErrKeyNotFound)
+
+			assert.Equal(t, "Servers[1]", fieldErrs[3].Path)
+			assert.Equal(t, "servers.1", fieldErrs[3].Key)
+			assert.Nil(t, fieldErrs[3].Engine)
+			assert.ErrorIs(t, fieldErrs[3], errMustBePositive)
+
+			assert.Equal(t, `Limits["memory"]`, fieldErrs[4].Path)
+			assert.Equal(t, "limits.memory", fieldErrs[4].Key)
+			assert.ErrorIs(t, fieldErrs[4], ErrTypeMismatch)
+
+			assert.Equal(t, "b.example.com", cfg.Servers[1].Host)
+			assert.Equal(t, 2, cfg.Limits["cpu"])
+		})
+
+		t.Run("should validate the structs whose fields failed", func(t *testing.T) {
+			manager := NewManager(WithCollectAllErrors())
+			manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+				"name":    "app",
+				"servers": []interface{}{map[string]interface{}{"port": -1}},
+			}))
+
+			var cfg MyTestConfigWithErrors
+			err := manager.Populate(&cfg)
+
+			var fieldErrs FieldErrors
+			require.ErrorAs(t, err, &fieldErrs)
+			require.Len(t, fieldErrs, 2)
+			assert.Equal(t, "Servers[0].Host", fieldErrs[0].Path)
+			assert.ErrorIs(t, fieldErrs[0], ErrKeyNotFound)
+			assert.Equal(t, "Servers[0]", fieldErrs[1].Path)
+			assert.ErrorIs(t, fieldErrs[1], errMustBePositive)
+		})
+
+		t.Run("should report type mismatches for values that cannot be parsed", func(t *testing.T) {
+			manager := NewManager(WithCollectAllErrors())
+			manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+				"name":      "app",
+				"servers.0": map[string]interface{}{"host": "a.example.com", "port": 80},
+			}))
+			manager.AddPlainEngine(newStringMapEngine(map[string]string{"port": "eighty"}, nil))
+
+			var cfg MyTestConfigWithErrors
+			err := manager.Populate(&cfg)
+			require.ErrorIs(t, err, ErrTypeMismatch)
+			assert.EqualError(t, err, `Port: type mismatch: port: strconv.Atoi: parsing "eighty": invalid syntax`)
+		})
+
+		t.Run("should return the first error when not enabled", func(t *testing.T) {
+			manager := NewManager()
+			manager.AddPlainEngine(engine)
+
+			var cfg MyTestConfigWithErrors
+			err := manager.Populate(&cfg)
+			require.ErrorIs(t, err, ErrKeyNotFound)
+			assert.EqualError(t, err, "key not found: name")
+		})
+	})
 }
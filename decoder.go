@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// decodeFunc decodes a raw string into a value of the type it is registered for.
+type decodeFunc func(raw string) (reflect.Value, error)
+
+// decoderRegistry maps types to the functions decoding their values from strings.
+type decoderRegistry map[reflect.Type]decodeFunc
+
+var fileModeType = reflect.TypeOf(os.FileMode(0))
+
+// builtinDecoders decode the standard library types that do not implement encoding.TextUnmarshaler, or whose text
+// form is not the one written in configs, like the octal file modes.
+var builtinDecoders = decoderRegistry{
+	reflect.TypeOf((*regexp.Regexp)(nil)): newDecodeFunc(regexp.Compile),
+	reflect.TypeOf((*url.URL)(nil)):       newDecodeFunc(url.Parse),
+	reflect.TypeOf(net.IP(nil)):           newDecodeFunc(parseIP),
+	reflect.TypeOf(net.IPNet{}):           newDecodeFunc(parseIPNet),
+	reflect.TypeOf(netip.Prefix{}):        newDecodeFunc(netip.ParsePrefix),
+	reflect.TypeOf(netip.AddrPort{}):      newDecodeFunc(netip.ParseAddrPort),
+	reflect.TypeOf((*time.Location)(nil)): newDecodeFunc(time.LoadLocation),
+	fileModeType:                          newDecodeFunc(parseFileMode),
+}
+
+// WithDecoder registers decode to read the values of type T from strings, as RegisterDecoder does.
+func WithDecoder[T any](decode func(raw string) (T, error)) Option {
+	return func(m *Manager) {
+		m.RegisterDecoder(reflect.TypeFor[T](), func(raw string) (interface{}, error) {
+			return decode(raw)
+		})
+	}
+}
+
+// RegisterDecoder registers decode to read the values of type t from strings. Populate uses it for the fields of type
+// t, or pointers to it, and for the elements of slices and the values of maps of type t, before any other way of
+// reading them, so it also replaces the encoding.TextUnmarshaler of t and the built-in decoders.
+//
+// The values returned by decode must be assignable to t. When an engine holds a value that is not a string for the
+// key, like a number in a YAML file, it is read according to the kind of t instead.
+func (m *Manager) RegisterDecoder(t reflect.Type, decode func(raw string) (interface{}, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.decoders == nil {
+		m.decoders = make(decoderRegistry)
+	}
+	m.decoders[t] = func(raw string) (reflect.Value, error) {
+		value, err := decode(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		result := reflect.New(t).Elem()
+		if value == nil {
+			return result, nil
+		}
+		if v := reflect.ValueOf(value); !v.Type().AssignableTo(t) {
+			return reflect.Value{}, fmt.Errorf("%w: %T decoded for %s", ErrTypeMismatch, value, t)
+		}
+		result.Set(reflect.ValueOf(value))
+		return result, nil
+	}
+}
+
+// newDecodeFunc adapts decode into a decodeFunc.
+func newDecodeFunc[T any](decode func(raw string) (T, error)) decodeFunc {
+	return func(raw string) (reflect.Value, error) {
+		value, err := decode(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&value).Elem(), nil
+	}
+}
+
+// lookup returns the decoder of the type t, registered in r or built in. The types whose pointer type has a decoder,
+// like url.URL, are decoded by it and dereferenced.
+func (r decoderRegistry) lookup(t reflect.Type) (decodeFunc, bool) {
+	if decode, ok := r.find(t); ok {
+		return decode, true
+	}
+	if t.Kind() == reflect.Ptr {
+		return nil, false
+	}
+	decode, ok := r.find(reflect.PointerTo(t))
+	if !ok {
+		return nil, false
+	}
+	return func(raw string) (reflect.Value, error) {
+		value, err := decode(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if value.IsNil() {
+			return reflect.Zero(t), nil
+		}
+		return value.Elem(), nil
+	}, true
+}
+
+func (r decoderRegistry) find(t reflect.Type) (decodeFunc, bool) {
+	if decode, ok := r[t]; ok {
+		return decode, true
+	}
+	decode, ok := builtinDecoders[t]
+	return decode, ok
+}
+
+// has reports whether the values of type t are read by a decoder.
+func (r decoderRegistry) has(t reflect.Type) bool {
+	_, ok := r.lookup(t)
+	return ok
+}
+
+// isStructType is isStructType taking the decoders of r into account: the structs they read are read from a single
+// key.
+func (r decoderRegistry) isStructType(t reflect.Type) bool {
+	return isStructType(t) && !r.has(t)
+}
+
+// isLeafType is isLeafType taking the decoders of r into account: the types they read, and the slices of them, are
+// read from a single key.
+func (r decoderRegistry) isLeafType(t reflect.Type) bool {
+	if r.has(t) || (t.Kind() == reflect.Slice && r.has(t.Elem())) {
+		return true
+	}
+	return isLeafType(t)
+}
+
+func parseIP(raw string) (net.IP, error) {
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: raw}
+	}
+	return ip, nil
+}
+
+func parseIPNet(raw string) (net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(raw)
+	if err != nil {
+		return net.IPNet{}, err
+	}
+	return *ipNet, nil
+}
+
+// parseFileMode parses an octal file mode, like `0644`, `644` or `0o644`.
+func parseFileMode(raw string) (os.FileMode, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(raw, "0o"), "0O")
+	mode, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode %q: %w", raw, err)
+	}
+	return os.FileMode(mode), nil
+}
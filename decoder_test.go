@@ -0,0 +1,235 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestMoney struct {
+	Cents    int64
+	Currency string
+}
+
+func parseMyTestMoney(raw string) (MyTestMoney, error) {
+	amount, currency, ok := strings.Cut(raw, " ")
+	if !ok {
+		return MyTestMoney{}, errors.New("missing currency")
+	}
+	cents, err := strconv.ParseInt(strings.ReplaceAll(amount, ".", ""), 10, 64)
+	if err != nil {
+		return MyTestMoney{}, err
+	}
+	return MyTestMoney{Cents: cents, Currency: currency}, nil
+}
+
+func (m MyTestMoney) String() string {
+	return fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency)
+}
+
+type MyTestConfigWithMoney struct {
+	Price  MyTestMoney            `config:"price" default:"9.99 EUR"`
+	Fees   []MyTestMoney          `config:"fees"`
+	Limits map[string]MyTestMoney `config:"limits"`
+}
+
+type MyTestConfigWithDecoders struct {
+	Pattern  *regexp.Regexp      `config:"pattern"`
+	Endpoint *url.URL            `config:"endpoint"`
+	Proxy    url.URL             `config:"proxy"`
+	IP       net.IP              `config:"ip"`
+	Network  net.IPNet           `config:"network"`
+	Prefix   netip.Prefix        `config:"prefix"`
+	Listen   netip.AddrPort      `config:"listen"`
+	Location *time.Location      `config:"location"`
+	Mode     os.FileMode         `config:"mode"`
+	Peers    []net.IP            `config:"peers"`
+	Mirrors  map[string]*url.URL `config:"mirrors"`
+	Missing  *url.URL            `config:"missing"`
+}
+
+func TestManager_RegisterDecoder(t *testing.T) {
+	t.Run("should read the types with a built-in decoder", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+			"pattern":  "^[a-z]+$",
+			"endpoint": "https://api.local/v1",
+			"proxy":    "http://proxy.local:3128",
+			"ip":       "10.0.0.1",
+			"network":  "10.0.0.0/8",
+			"prefix":   "192.168.0.0/16",
+			"listen":   "0.0.0.0:8080",
+			"location": "UTC",
+			"mode":     "0640",
+			"peers":    []interface{}{"10.0.0.2", "10.0.0.3"},
+			"mirrors":  map[string]interface{}{"eu": "https://eu.local"},
+		}))
+
+		var cfg MyTestConfigWithDecoders
+		require.NoError(t, manager.Populate(&cfg))
+		assert.True(t, cfg.Pattern.MatchString("abc"))
+		assert.Equal(t, "https://api.local/v1", cfg.Endpoint.String())
+		assert.Equal(t, "proxy.local:3128", cfg.Proxy.Host)
+		assert.Equal(t, "10.0.0.1", cfg.IP.String())
+		assert.Equal(t, "10.0.0.0/8", cfg.Network.String())
+		assert.Equal(t, netip.MustParsePrefix("192.168.0.0/16"), cfg.Prefix)
+		assert.Equal(t, netip.MustParseAddrPort("0.0.0.0:8080"), cfg.Listen)
+		assert.Equal(t, time.UTC, cfg.Location)
+		assert.Equal(t, os.FileMode(0o640), cfg.Mode)
+		assert.Equal(t, []net.IP{net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.3")}, cfg.Peers)
+		require.Contains(t, cfg.Mirrors, "eu")
+		assert.Equal(t, "eu.local", cfg.Mirrors["eu"].Host)
+		assert.Nil(t, cfg.Missing)
+	})
+
+	t.Run("should read the types with a registered decoder", func(t *testing.T) {
+		var cfg struct {
+			Price  MyTestMoney            `config:"price"`
+			Max    *MyTestMoney           `config:"max"`
+			Fees   []MyTestMoney          `config:"fees" default:"1.00 EUR,2.50 EUR"`
+			Limits map[string]MyTestMoney `config:"limits"`
+		}
+		manager := NewManager(WithDecoder(parseMyTestMoney))
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+			"price":  "9.99 EUR",
+			"max":    "100.00 USD",
+			"limits": map[string]interface{}{"daily": "50.00 EUR"},
+		}))
+
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, MyTestMoney{Cents: 999, Currency: "EUR"}, cfg.Price)
+		assert.Equal(t, &MyTestMoney{Cents: 10000, Currency: "USD"}, cfg.Max)
+		assert.Equal(t, []MyTestMoney{{Cents: 100, Currency: "EUR"}, {Cents: 250, Currency: "EUR"}}, cfg.Fees)
+		assert.Equal(t, map[string]MyTestMoney{"daily": {Cents: 5000, Currency: "EUR"}}, cfg.Limits)
+	})
+
+	t.Run("should replace the built-in decoders", func(t *testing.T) {
+		var cfg struct {
+			Mode os.FileMode `config:"mode"`
+		}
+		manager := NewManager()
+		manager.RegisterDecoder(reflect.TypeOf(os.FileMode(0)), func(raw string) (interface{}, error) {
+			return os.FileMode(0o600), nil
+		})
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"mode": "0644"}))
+
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, os.FileMode(0o600), cfg.Mode)
+	})
+
+	t.Run("should read the numbers of the engine by kind", func(t *testing.T) {
+		var cfg struct {
+			Mode os.FileMode `config:"mode"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"mode": 0o644}))
+
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, os.FileMode(0o644), cfg.Mode)
+	})
+
+	t.Run("should fail naming the key when the value cannot be decoded", func(t *testing.T) {
+		var cfg struct {
+			Pattern *regexp.Regexp `config:"pattern"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"pattern": "[a-z"}))
+
+		err := manager.Populate(&cfg)
+		require.ErrorContains(t, err, "missing closing ]")
+		assert.ErrorContains(t, err, ": pattern")
+	})
+
+	t.Run("should fail when the decoder returns another type", func(t *testing.T) {
+		var cfg struct {
+			Price MyTestMoney `config:"price"`
+		}
+		manager := NewManager()
+		manager.RegisterDecoder(reflect.TypeOf(MyTestMoney{}), func(raw string) (interface{}, error) {
+			return raw, nil
+		})
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"price": "9.99 EUR"}))
+
+		require.ErrorIs(t, manager.Populate(&cfg), ErrTypeMismatch)
+	})
+}
+
+func TestManager_Dump_builtinDecoders(t *testing.T) {
+	var cfg MyTestConfigWithDecoders
+	manager := NewManager()
+	manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+		"endpoint": "https://api.local/v1",
+		"network":  "10.0.0.0/8",
+		"mode":     "0640",
+	}))
+	require.NoError(t, manager.Populate(&cfg))
+
+	data, err := manager.Dump(&cfg, DumpEnv)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "ENDPOINT=https://api.local/v1\n")
+	assert.Contains(t, string(data), "NETWORK=10.0.0.0/8\n")
+	assert.Contains(t, string(data), "MODE=0640\n")
+}
+
+func TestManager_Dump_decoders(t *testing.T) {
+	cfg := MyTestConfigWithMoney{
+		Price:  MyTestMoney{Cents: 999, Currency: "EUR"},
+		Fees:   []MyTestMoney{{Cents: 100, Currency: "EUR"}},
+		Limits: map[string]MyTestMoney{"daily": {Cents: 5000, Currency: "EUR"}},
+	}
+	manager := NewManager(WithDecoder(parseMyTestMoney))
+
+	data, err := manager.Dump(&cfg, DumpYAML)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+price: 9.99 EUR
+fees: [1.00 EUR]
+limits:
+  daily: 50.00 EUR
+`, string(data))
+
+	var populated MyTestConfigWithMoney
+	manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader(data)))
+	require.NoError(t, manager.Populate(&populated))
+	assert.Equal(t, cfg, populated)
+}
+
+func TestManager_JSONSchema_decoders(t *testing.T) {
+	manager := NewManager(WithDecoder(parseMyTestMoney))
+
+	data, err := manager.JSONSchema(&MyTestConfigWithMoney{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"price": {"type": "string", "default": "9.99 EUR"},
+			"fees": {"type": "array", "items": {"type": "string"}},
+			"limits": {"type": ["object", "string"], "additionalProperties": {"type": "string"}}
+		}
+	}`, string(data))
+}
+
+func TestManager_DocFields_decoders(t *testing.T) {
+	manager := NewManager(WithDecoder(parseMyTestMoney))
+
+	fields, err := manager.DocFields(&MyTestConfigWithMoney{})
+	require.NoError(t, err)
+	assert.Equal(t, []DocField{
+		{Name: "price", Kind: DocValue, Type: "config.MyTestMoney", Text: true, Default: "9.99 EUR", HasDefault: true},
+		{Name: "fees", Kind: DocList, Type: "[]config.MyTestMoney", Text: true},
+		{Name: "limits", Kind: DocMap, Type: "map[string]config.MyTestMoney", Text: true},
+	}, fields)
+}
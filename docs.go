@@ -0,0 +1,384 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// DocFormat is the format in which Manager.Docs writes the documentation of a config.
+type DocFormat string
+
+const (
+	// DocMarkdown writes a Markdown table listing the keys, their environment variables, types, defaults and
+	// descriptions.
+	DocMarkdown DocFormat = "markdown"
+	// DocEnvExample writes a `.env.example` file, with a `KEY=default` line for each key.
+	DocEnvExample DocFormat = "env"
+	// DocYAMLSample writes a sample YAML file, with the keys that have no default commented out.
+	DocYAMLSample DocFormat = "yaml"
+)
+
+// DocKind is how the value of a DocField is read.
+type DocKind int
+
+const (
+	// DocValue is a field read from a single value, like a string, a number or a time.Duration.
+	DocValue DocKind = iota
+	// DocList is a slice of values.
+	DocList
+	// DocMap is a map of values.
+	DocMap
+	// DocStruct is a struct, whose fields are nested under its key.
+	DocStruct
+	// DocStructList is a slice of structs.
+	DocStructList
+	// DocStructMap is a map of structs.
+	DocStructMap
+)
+
+// DocField describes a field of a config struct, as documented by Manager.RenderDocs.
+type DocField struct {
+	// Name is the key of the field, relative to the struct holding it.
+	Name string
+
+	// Kind is how the value of the field is read.
+	Kind DocKind
+
+	// Type is the Go type of the field, like `time.Duration`.
+	Type string
+
+	// Text reports whether the values, or the elements of lists and maps, are read from strings, so they are quoted
+	// in YAML when needed. It is not set for numbers and booleans.
+	Text bool
+
+	// Default is the value of the `default` tag, when HasDefault is set.
+	Default    string
+	HasDefault bool
+
+	Required    bool
+	Secret      bool
+	Description string
+
+	// Fields holds the fields of the structs, for the DocStruct, DocStructList and DocStructMap kinds.
+	Fields []DocField
+}
+
+// Docs writes the documentation of the fields of cfg in the given format, as RenderDocs does for the fields returned
+// by Manager.DocFields.
+func (m *Manager) Docs(cfg interface{}, format DocFormat, opts ...EnvOption) ([]byte, error) {
+	fields, err := m.DocFields(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return m.RenderDocs(fields, format, opts...)
+}
+
+// DocFields describes the fields of the config struct cfg, following their `config`, `default` and `desc` tags as
+// Populate does. Fields of types Populate does not read are left out, and so are the fields of recursive structs.
+//
+// The types with a decoder registered in a Manager are only described as text by Manager.DocFields.
+func DocFields(cfg interface{}) ([]DocField, error) {
+	return docFieldsOf(cfg, nil)
+}
+
+// DocFields describes the fields of cfg as the DocFields function does, describing the types with a decoder
+// registered in the Manager as text values.
+func (m *Manager) DocFields(cfg interface{}) ([]DocField, error) {
+	return docFieldsOf(cfg, m.decoders)
+}
+
+func docFieldsOf(cfg interface{}, decoders decoderRegistry) ([]DocField, error) {
+	t := reflect.TypeOf(cfg)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || !decoders.isStructType(t) {
+		return nil, fmt.Errorf("%w: %v", ErrTypeMismatch, t)
+	}
+	return docFields(t, nil, decoders), nil
+}
+
+// docFields describes the fields of the struct t. The stack holds the structs being described.
+func docFields(t reflect.Type, stack []reflect.Type, decoders decoderRegistry) []DocField {
+	stack = append(stack, t)
+	fields := make([]DocField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := parseFieldTag(t.Field(i))
+		if !ok {
+			continue
+		}
+		ft := derefType(t.Field(i).Type)
+		field := DocField{
+			Name:        tag.name,
+			Type:        ft.String(),
+			Default:     tag.defaultValue,
+			HasDefault:  tag.hasDefault,
+			Required:    tag.required,
+			Secret:      tag.secret,
+			Description: tag.description,
+		}
+
+		var structType reflect.Type
+		switch {
+		case decoders.isStructType(ft):
+			field.Kind, structType = DocStruct, ft
+		case ft.Kind() == reflect.Slice && decoders.isStructType(derefType(ft.Elem())):
+			field.Kind, structType = DocStructList, derefType(ft.Elem())
+		case ft.Kind() == reflect.Map:
+			if ft.Key().Kind() != reflect.String {
+				continue
+			}
+			if decoders.isStructType(ft.Elem()) {
+				field.Kind, structType = DocStructMap, ft.Elem()
+			} else if leafSchema(ft.Elem(), decoders) != nil {
+				field.Kind, field.Text = DocMap, isTextType(ft.Elem(), decoders)
+			} else {
+				continue
+			}
+		case leafSchema(ft, decoders) == nil:
+			continue
+		case ft.Kind() == reflect.Slice && !reflect.PointerTo(ft).Implements(textUnmarshalerType) && !decoders.has(ft):
+			field.Kind, field.Text = DocList, isTextType(ft.Elem(), decoders)
+		case ft == timeType && (tag.layout == timeLayoutUnix || tag.layout == timeLayoutUnixMilli):
+			// The Unix times are numbers.
+			field.Kind = DocValue
+		default:
+			field.Kind, field.Text = DocValue, isTextType(ft, decoders)
+		}
+
+		if structType != nil {
+			if slices.Contains(stack, structType) {
+				continue
+			}
+			field.Fields = docFields(structType, stack, decoders)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// isTextType reports whether the values of type t are read from strings.
+func isTextType(t reflect.Type, decoders decoderRegistry) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return t == durationType || reflect.PointerTo(t).Implements(textUnmarshalerType) || decoders.has(t)
+	}
+	return true
+}
+
+// RenderDocs writes the documentation of the given fields in the given format. The keys are joined by the key
+// separator of the Manager and the opts set how they are mapped into environment variable names, like WithPrefix.
+//
+// The elements of the slices of structs are keyed by `<n>` in the Markdown table and by `0`, the first element, in the
+// other formats. The entries of the maps of structs are keyed by `<name>`, and left out of the `.env.example`.
+func (m *Manager) RenderDocs(fields []DocField, format DocFormat, opts ...EnvOption) ([]byte, error) {
+	env := NewEnvEngine(opts...)
+	var buf bytes.Buffer
+	switch format {
+	case DocMarkdown:
+		buf.WriteString("| Key | Env var | Type | Default | Required | Secret | Description |\n")
+		buf.WriteString("|-----|---------|------|---------|----------|--------|-------------|\n")
+		m.walkDocs(fields, "", "<n>", func(key string, field DocField) {
+			var def string
+			if field.HasDefault {
+				def = "`" + field.Default + "`"
+			}
+			_, _ = fmt.Fprintf(&buf, "| `%s` | `%s` | `%s` | %s | %s | %s | %s |\n", key, env.getKey(key), field.Type,
+				escapeMarkdownCell(def), yesOrEmpty(field.Required), yesOrEmpty(field.Secret),
+				escapeMarkdownCell(field.Description))
+		})
+	case DocEnvExample:
+		first := true
+		m.walkDocs(fields, "", "0", func(key string, field DocField) {
+			if strings.Contains(key, "<name>") {
+				return
+			}
+			if !first {
+				buf.WriteByte('\n')
+			}
+			first = false
+			for _, note := range docNotes(field) {
+				buf.WriteString("# " + note + "\n")
+			}
+			_, _ = fmt.Fprintf(&buf, "%s=%s\n", env.getKey(key), quoteEnvValue(field.Default))
+		})
+	case DocYAMLSample:
+		lines, _ := yamlSample(fields, 0)
+		for _, line := range lines {
+			buf.WriteString(line.String())
+			buf.WriteByte('\n')
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+	}
+	return buf.Bytes(), nil
+}
+
+// walkDocs calls f with the fields read from a single key, like values, lists and maps of values, and their keys
+// nested under keyPrefix. The elements of slices of structs are keyed by index.
+func (m *Manager) walkDocs(fields []DocField, keyPrefix, index string, f func(key string, field DocField)) {
+	for _, field := range fields {
+		key := field.Name
+		if keyPrefix != "" {
+			key = keyPrefix + m.keySeparator + key
+		}
+		switch field.Kind {
+		case DocStruct:
+			m.walkDocs(field.Fields, key, index, f)
+		case DocStructList:
+			m.walkDocs(field.Fields, key+m.keySeparator+index, index, f)
+		case DocStructMap:
+			m.walkDocs(field.Fields, key+m.keySeparator+"<name>", index, f)
+		default:
+			f(key, field)
+		}
+	}
+}
+
+// docNotes returns the comments describing field in the `.env.example` and sample YAML files.
+func docNotes(field DocField) []string {
+	var notes []string
+	if field.Description != "" {
+		notes = append(notes, field.Description)
+	}
+	note := field.Type
+	if field.Required {
+		note += ", required"
+	}
+	if field.Secret {
+		note += ", secret"
+	}
+	return append(notes, note)
+}
+
+func yesOrEmpty(b bool) string {
+	if b {
+		return "yes"
+	}
+	return ""
+}
+
+func escapeMarkdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// sampleLine is a line of the sample YAML file.
+type sampleLine struct {
+	depth int
+	text  string
+	// comment is set for the lines written as comments: the notes describing the fields and the keys without a value.
+	comment bool
+	// note is set for the notes describing the fields.
+	note bool
+}
+
+func (line sampleLine) String() string {
+	indent := strings.Repeat("  ", line.depth)
+	if line.comment {
+		return indent + "# " + line.text
+	}
+	return indent + line.text
+}
+
+// yamlSample returns the lines of the sample YAML file describing fields, at the given depth, and whether any of them
+// sets a value. The fields without a default value are commented out, and so are the structs with none of their
+// fields set.
+func yamlSample(fields []DocField, depth int) ([]sampleLine, bool) {
+	var (
+		lines []sampleLine
+		set   bool
+	)
+	for _, field := range fields {
+		for _, note := range docNotes(field) {
+			lines = append(lines, sampleLine{depth: depth, text: note, comment: true, note: true})
+		}
+
+		switch field.Kind {
+		case DocStruct, DocStructList, DocStructMap:
+			childDepth := depth + 1
+			if field.Kind != DocStruct {
+				childDepth++
+			}
+			children, childrenSet := yamlSample(field.Fields, childDepth)
+			if field.Kind == DocStructMap {
+				// The placeholder name would be populated as an entry, so the entries are only shown commented out.
+				children = append([]sampleLine{{depth: depth + 1, text: "<name>:", comment: true}}, children...)
+				childrenSet = false
+			}
+			if field.Kind == DocStructList {
+				// The dash starting the element goes before its first key set, or its first key when none is, and the
+				// lines before it are aligned with the dash.
+				for i := range children {
+					if children[i].depth == childDepth && !children[i].note && (!children[i].comment || !childrenSet) {
+						children[i].depth--
+						children[i].text = "- " + children[i].text
+						break
+					}
+					children[i].depth--
+				}
+			}
+			lines = append(lines, sampleLine{depth: depth, text: field.Name + ":", comment: !childrenSet})
+			for _, child := range children {
+				child.comment = child.comment || !childrenSet
+				lines = append(lines, child)
+			}
+			set = set || childrenSet
+		default:
+			if !field.HasDefault {
+				lines = append(lines, sampleLine{depth: depth, text: field.Name + ":", comment: true})
+				continue
+			}
+			lines = append(lines, sampleLine{depth: depth, text: field.Name + ": " + yamlSampleValue(field)})
+			set = true
+		}
+	}
+	return lines, set
+}
+
+// yamlSampleValue formats the default value of field as a YAML flow value.
+func yamlSampleValue(field DocField) string {
+	switch field.Kind {
+	case DocList:
+		if strings.TrimSpace(field.Default) == "" {
+			return "[]"
+		}
+		items := strings.Split(field.Default, ",")
+		for i, item := range items {
+			items[i] = yamlScalar(strings.TrimSpace(item), field.Text)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case DocMap:
+		entries, err := parseMapEntries(field.Name, field.Default)
+		if err != nil {
+			return yamlScalar(field.Default, true)
+		}
+		names := make([]string, 0, len(entries))
+		for name := range entries {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for i, name := range names {
+			names[i] = yamlScalar(name, true) + ": " + yamlScalar(entries[name], field.Text)
+		}
+		return "{" + strings.Join(names, ", ") + "}"
+	}
+	return yamlScalar(field.Default, field.Text)
+}
+
+// yamlScalar formats value as a YAML scalar, quoted when it would not be read as a string and text is set.
+func yamlScalar(value string, text bool) string {
+	if !text {
+		return value
+	}
+	data, err := yamlv3.Marshal(value)
+	if err != nil {
+		return value
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestServerWithDocs struct {
+	Host string `config:"host,required" desc:"Host of the server."`
+	Port int    `config:"port" default:"80"`
+}
+
+type MyTestConfigWithDocs struct {
+	Name      string                          `config:"name" default:"my app" desc:"Name of the app | service."`
+	Debug     bool                            `config:"debug" default:"false"`
+	Timeout   time.Duration                   `config:"timeout" default:"30s"`
+	Hosts     []string                        `config:"hosts" default:"a.local,b.local"`
+	Labels    map[string]string               `config:"labels" default:"team=core"`
+	Password  string                          `config:"password,secret,required"`
+	Primary   MyTestServerWithDocs            `config:"primary"`
+	Servers   []MyTestServerWithDocs          `config:"servers"`
+	Upstreams map[string]MyTestServerWithDocs `config:"upstreams"`
+	Ignored   chan int                        `config:"ignored"`
+}
+
+func TestManager_Docs(t *testing.T) {
+	t.Run("should write a Markdown table", func(t *testing.T) {
+		data, err := NewManager().Docs(&MyTestConfigWithDocs{}, DocMarkdown, WithPrefix("APP_"))
+		require.NoError(t, err)
+		assert.Equal(t, "| Key | Env var | Type | Default | Required | Secret | Description |\n"+
+			"|-----|---------|------|---------|----------|--------|-------------|\n"+
+			"| `name` | `APP_NAME` | `string` | `my app` |  |  | Name of the app \\| service. |\n"+
+			"| `debug` | `APP_DEBUG` | `bool` | `false` |  |  |  |\n"+
+			"| `timeout` | `APP_TIMEOUT` | `time.Duration` | `30s` |  |  |  |\n"+
+			"| `hosts` | `APP_HOSTS` | `[]string` | `a.local,b.local` |  |  |  |\n"+
+			"| `labels` | `APP_LABELS` | `map[string]string` | `team=core` |  |  |  |\n"+
+			"| `password` | `APP_PASSWORD` | `string` |  | yes | yes |  |\n"+
+			"| `primary.host` | `APP_PRIMARY_HOST` | `string` |  | yes |  | Host of the server. |\n"+
+			"| `primary.port` | `APP_PRIMARY_PORT` | `int` | `80` |  |  |  |\n"+
+			"| `servers.<n>.host` | `APP_SERVERS_<N>_HOST` | `string` |  | yes |  | Host of the server. |\n"+
+			"| `servers.<n>.port` | `APP_SERVERS_<N>_PORT` | `int` | `80` |  |  |  |\n"+
+			"| `upstreams.<name>.host` | `APP_UPSTREAMS_<NAME>_HOST` | `string` |  | yes |  | Host of the server. |\n"+
+			"| `upstreams.<name>.port` | `APP_UPSTREAMS_<NAME>_PORT` | `int` | `80` |  |  |  |\n", string(data))
+	})
+
+	t.Run("should write a .env.example", func(t *testing.T) {
+		data, err := NewManager().Docs(&MyTestConfigWithDocs{}, DocEnvExample, WithPrefix("APP_"))
+		require.NoError(t, err)
+		assert.Equal(t, `# Name of the app | service.
+# string
+APP_NAME="my app"
+
+# bool
+APP_DEBUG=false
+
+# time.Duration
+APP_TIMEOUT=30s
+
+# []string
+APP_HOSTS=a.local,b.local
+
+# map[string]string
+APP_LABELS=team=core
+
+# string, required, secret
+APP_PASSWORD=
+
+# Host of the server.
+# string, required
+APP_PRIMARY_HOST=
+
+# int
+APP_PRIMARY_PORT=80
+
+# Host of the server.
+# string, required
+APP_SERVERS_0_HOST=
+
+# int
+APP_SERVERS_0_PORT=80
+`, string(data))
+	})
+
+	t.Run("should write a sample YAML", func(t *testing.T) {
+		data, err := NewManager().Docs(&MyTestConfigWithDocs{}, DocYAMLSample)
+		require.NoError(t, err)
+		assert.Equal(t, `# Name of the app | service.
+# string
+name: my app
+# bool
+debug: false
+# time.Duration
+timeout: 30s
+# []string
+hosts: [a.local, b.local]
+# map[string]string
+labels: {team: core}
+# string, required, secret
+# password:
+# config.MyTestServerWithDocs
+primary:
+  # Host of the server.
+  # string, required
+  # host:
+  # int
+  port: 80
+# []config.MyTestServerWithDocs
+servers:
+  # Host of the server.
+  # string, required
+  # host:
+  # int
+  - port: 80
+# map[string]config.MyTestServerWithDocs
+# upstreams:
+  # <name>:
+    # Host of the server.
+    # string, required
+    # host:
+    # int
+    # port: 80
+`, string(data))
+
+		t.Run("which populates the default values", func(t *testing.T) {
+			var cfg struct {
+				Name    string                 `config:"name"`
+				Debug   bool                   `config:"debug"`
+				Timeout time.Duration          `config:"timeout"`
+				Hosts   []string               `config:"hosts"`
+				Labels  map[string]string      `config:"labels"`
+				Primary MyTestServerWithDocs   `config:"primary"`
+				Servers []MyTestServerWithDocs `config:"servers"`
+			}
+			manager := NewManager()
+			// The required keys, commented out in the sample, are set by another engine.
+			manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader(data)), NewMapEngine(map[string]interface{}{
+				"primary.host":   "primary.local",
+				"servers.0.host": "s0.local",
+			}))
+			require.NoError(t, manager.Populate(&cfg))
+			assert.Equal(t, "my app", cfg.Name)
+			assert.Equal(t, 30*time.Second, cfg.Timeout)
+			assert.Equal(t, []string{"a.local", "b.local"}, cfg.Hosts)
+			assert.Equal(t, map[string]string{"team": "core"}, cfg.Labels)
+			assert.Equal(t, MyTestServerWithDocs{Host: "primary.local", Port: 80}, cfg.Primary)
+			assert.Equal(t, []MyTestServerWithDocs{{Host: "s0.local", Port: 80}}, cfg.Servers)
+		})
+	})
+
+	t.Run("should fail on unsupported formats", func(t *testing.T) {
+		_, err := NewManager().Docs(&MyTestConfigWithDocs{}, DocFormat("html"))
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
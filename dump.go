@@ -0,0 +1,327 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// DumpFormat is the format in which Manager.Dump writes a config.
+type DumpFormat string
+
+const (
+	// DumpYAML writes the config as a YAML document, readable by YAMLEngine.
+	DumpYAML DumpFormat = "yaml"
+	// DumpJSON writes the config as a JSON object, readable by JSONEngine.
+	DumpJSON DumpFormat = "json"
+	// DumpEnv writes the config as `KEY=value` lines, readable by DotenvEngine.
+	DumpEnv DumpFormat = "env"
+)
+
+// SecretMask replaces the values of the secret fields in the output of Manager.Dump.
+const SecretMask = "******"
+
+// Dump writes the populated config cfg in the given format, walking its fields by their `config` tags as Populate
+// does. The values of the secret fields are replaced with SecretMask, and nil pointers are left out.
+//
+// The output can be read again by the engine of the format, giving back the same config except for the secrets. To
+// keep it so, the `${` in the values are written as `$${`, which is how they are escaped from the interpolation. The
+// opts set how the keys are mapped into environment variable names for DumpEnv, like WithPrefix.
+func (m *Manager) Dump(cfg interface{}, format DumpFormat, opts ...EnvOption) ([]byte, error) {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrTypeMismatch, v.Type())
+	}
+	tree := dumpStruct(v, !m.noInterpolation, m.decoders)
+
+	switch format {
+	case DumpYAML:
+		var buf bytes.Buffer
+		encoder := yamlv3.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(tree); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case DumpJSON:
+		data, err := json.MarshalIndent(tree, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case DumpEnv:
+		env := NewEnvEngine(opts...)
+		var buf bytes.Buffer
+		m.dumpEnv(&buf, &env, "", tree)
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, format)
+}
+
+// dumpMap is a map that keeps the order of its entries, which is the order of the fields of the struct it is built
+// from.
+type dumpMap []dumpEntry
+
+type dumpEntry struct {
+	key   string
+	value interface{}
+}
+
+// get returns the value of the entry with the given key.
+func (d dumpMap) get(key string) (interface{}, bool) {
+	for _, entry := range d {
+		if entry.key == key {
+			return entry.value, true
+		}
+	}
+	return nil, false
+}
+
+// set sets the value of the entry with the given key, appending it when there is none.
+func (d dumpMap) set(key string, value interface{}) dumpMap {
+	for i, entry := range d {
+		if entry.key == key {
+			d[i].value = value
+			return d
+		}
+	}
+	return append(d, dumpEntry{key, value})
+}
+
+// dumpLeafMap is a dumpMap built from a map of leaf values, which is written as a single `k1=v1,k2=v2` value in the
+// env format.
+type dumpLeafMap dumpMap
+
+func (d dumpMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, entry := range d {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(entry.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (d dumpMap) MarshalYAML() (interface{}, error) {
+	node := &yamlv3.Node{Kind: yamlv3.MappingNode}
+	for _, entry := range d {
+		value := &yamlv3.Node{}
+		if err := value.Encode(entry.value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: entry.key}, value)
+	}
+	return node, nil
+}
+
+func (d dumpLeafMap) MarshalJSON() ([]byte, error) {
+	return dumpMap(d).MarshalJSON()
+}
+
+func (d dumpLeafMap) MarshalYAML() (interface{}, error) {
+	return dumpMap(d).MarshalYAML()
+}
+
+// dumpStruct builds the tree of the tagged fields of the struct v. When escape is set, the `${` in strings are escaped
+// from the interpolation. The types read by the decoders are written as strings.
+func dumpStruct(v reflect.Value, escape bool, decoders decoderRegistry) dumpMap {
+	t := v.Type()
+	tree := make(dumpMap, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := parseFieldTag(t.Field(i))
+		if !ok {
+			continue
+		}
+		if value, ok := dumpValue(v.Field(i), tag, escape, decoders); ok {
+			tree = append(tree, dumpEntry{tag.name, value})
+		}
+	}
+	return tree
+}
+
+// dumpValue returns the value written for the field v, or false when it is left out.
+func dumpValue(v reflect.Value, tag fieldTag, escape bool, decoders decoderRegistry) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if tag.secret {
+		return SecretMask, true
+	}
+
+	switch {
+	case decoders.isStructType(v.Type()):
+		return dumpStruct(v, escape, decoders), true
+	case v.Kind() == reflect.Slice && decoders.isStructType(derefType(v.Type().Elem())):
+		if v.Len() == 0 {
+			return nil, false
+		}
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if item, ok := dumpValue(v.Index(i), fieldTag{}, escape, decoders); ok {
+				items = append(items, item)
+			}
+		}
+		return items, true
+	case v.Kind() == reflect.Map:
+		if v.Len() == 0 || v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		keys := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		slices.Sort(keys)
+		entries := make(dumpMap, 0, len(keys))
+		for _, key := range keys {
+			if value, ok := dumpValue(v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())), tag, escape, decoders); ok {
+				entries = append(entries, dumpEntry{key, value})
+			}
+		}
+		if decoders.isLeafType(v.Type().Elem()) {
+			return dumpLeafMap(entries), true
+		}
+		return entries, true
+	}
+	if v.Type() == timeType && tag.hasTimeOptions() {
+		return formatTime(v.Interface().(time.Time), tag), true
+	}
+	return dumpLeaf(v, escape && tag.interpolates(), decoders), true
+}
+
+// dumpLeaf returns the value written for the leaf value v, in the form its Engine getter reads it. When escape is set,
+// the `${` in strings are escaped from the interpolation.
+func dumpLeaf(v reflect.Value, escape bool, decoders decoderRegistry) interface{} {
+	str := func(s string) string {
+		if escape {
+			return strings.ReplaceAll(s, "${", "$${")
+		}
+		return s
+	}
+
+	// The value is copied, so the types implementing encoding.TextMarshaler with pointer receivers are found even when
+	// v is not addressable.
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	if marshaler, ok := ptr.Interface().(encoding.TextMarshaler); ok {
+		if text, err := marshaler.MarshalText(); err == nil {
+			return str(string(text))
+		}
+	}
+	if v.Type() == fileModeType {
+		return fmt.Sprintf("%#o", v.Uint())
+	}
+	// The types read with encoding.TextUnmarshaler or a decoder, but without a MarshalText, are expected to read what
+	// they print.
+	if stringer, ok := ptr.Interface().(fmt.Stringer); ok && (ptr.Type().Implements(textUnmarshalerType) ||
+		decoders.has(v.Type())) {
+		return str(stringer.String())
+	}
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = dumpLeaf(v.Index(i), escape, decoders)
+		}
+		return items
+	case reflect.String:
+		return str(v.String())
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32:
+		return float32(v.Float())
+	case reflect.Float64:
+		return v.Float()
+	}
+	return str(fmt.Sprint(v.Interface()))
+}
+
+// dumpEnv writes the tree as `KEY=value` lines, the keys nested under keyPrefix.
+func (m *Manager) dumpEnv(buf *bytes.Buffer, env *EnvEngine, keyPrefix string, tree dumpMap) {
+	for _, entry := range tree {
+		key := entry.key
+		if keyPrefix != "" {
+			key = keyPrefix + m.keySeparator + key
+		}
+		switch value := entry.value.(type) {
+		case dumpMap:
+			m.dumpEnv(buf, env, key, value)
+			continue
+		case []interface{}:
+			if len(value) > 0 {
+				if _, ok := value[0].(dumpMap); ok {
+					for i, item := range value {
+						m.dumpEnv(buf, env, key+m.keySeparator+strconv.Itoa(i), item.(dumpMap))
+					}
+					continue
+				}
+			}
+		}
+		fmt.Fprintf(buf, "%s=%s\n", env.getKey(key), quoteEnvValue(formatEnvValue(entry.value)))
+	}
+}
+
+// formatEnvValue formats a leaf value as EnvEngine parses it: slices are comma-separated and maps are written as
+// `k1=v1,k2=v2`.
+func formatEnvValue(value interface{}) string {
+	switch value := value.(type) {
+	case []interface{}:
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = formatEnvValue(item)
+		}
+		return strings.Join(items, ",")
+	case dumpLeafMap:
+		entries := make([]string, len(value))
+		for i, entry := range value {
+			entries[i] = entry.key + "=" + formatEnvValue(entry.value)
+		}
+		return strings.Join(entries, ",")
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// quoteEnvValue double quotes the values that would not be read as they are from an unquoted dotenv value.
+func quoteEnvValue(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n#\"'\\$") {
+		return value
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(value) + `"`
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	bs "github.com/inhies/go-bytesize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestConfigToDump struct {
+	Name     string            `config:"name"`
+	Greeting string            `config:"greeting"`
+	Template string            `config:"template,nointerpolate"`
+	Port     int               `config:"port"`
+	Ratio    float64           `config:"ratio"`
+	Debug    bool              `config:"debug"`
+	Timeout  time.Duration     `config:"timeout"`
+	MaxBody  bs.ByteSize       `config:"max_body"`
+	StartsAt time.Time         `config:"starts_at"`
+	Hosts    []string          `config:"hosts"`
+	Weights  []int             `config:"weights"`
+	Labels   map[string]string `config:"labels"`
+	Servers  []MyTestServer    `config:"servers"`
+	Primary  *MyTestServer     `config:"primary"`
+	Backup   *MyTestServer     `config:"backup"`
+	Password string            `config:"password,secret"`
+	Database struct {
+		Host string `config:"host"`
+		Port int    `config:"port"`
+	} `config:"db"`
+}
+
+func newTestConfigToDump() MyTestConfigToDump {
+	cfg := MyTestConfigToDump{
+		Name:     "app",
+		Greeting: `hello "world" # $HOME ${name}`,
+		Template: "${user}",
+		Port:     8080,
+		Ratio:    0.25,
+		Debug:    true,
+		Timeout:  90 * time.Second,
+		MaxBody:  bs.MB,
+		StartsAt: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		Hosts:    []string{"a.local", "b.local"},
+		Weights:  []int{1, 2},
+		Labels:   map[string]string{"team": "core", "env": "prod"},
+		Servers:  []MyTestServer{{Host: "s0.local", Port: 80}, {Host: "s1.local", Port: 81}},
+		Primary:  &MyTestServer{Host: "primary.local", Port: 5432},
+		Password: "s3cr3t",
+	}
+	cfg.Database.Host = "db.local"
+	cfg.Database.Port = 5432
+	return cfg
+}
+
+func TestManager_Dump(t *testing.T) {
+	t.Run("should write YAML", func(t *testing.T) {
+		cfg := newTestConfigToDump()
+		data, err := NewManager().Dump(&cfg, DumpYAML)
+		require.NoError(t, err)
+		assert.Equal(t, `name: app
+greeting: 'hello "world" # $HOME $${name}'
+template: ${user}
+port: 8080
+ratio: 0.25
+debug: true
+timeout: 1m30s
+max_body: 1.00MB
+starts_at: "2024-03-01T10:00:00Z"
+hosts:
+  - a.local
+  - b.local
+weights:
+  - 1
+  - 2
+labels:
+  env: prod
+  team: core
+servers:
+  - host: s0.local
+    port: 80
+  - host: s1.local
+    port: 81
+primary:
+  host: primary.local
+  port: 5432
+password: '******'
+db:
+  host: db.local
+  port: 5432
+`, string(data))
+	})
+
+	t.Run("should write env lines", func(t *testing.T) {
+		cfg := newTestConfigToDump()
+		data, err := NewManager().Dump(&cfg, DumpEnv, WithPrefix("APP_"))
+		require.NoError(t, err)
+		assert.Equal(t, `APP_NAME=app
+APP_GREETING="hello \"world\" # \$HOME \$\${name}"
+APP_TEMPLATE="\${user}"
+APP_PORT=8080
+APP_RATIO=0.25
+APP_DEBUG=true
+APP_TIMEOUT=1m30s
+APP_MAX_BODY=1.00MB
+APP_STARTS_AT=2024-03-01T10:00:00Z
+APP_HOSTS=a.local,b.local
+APP_WEIGHTS=1,2
+APP_LABELS=env=prod,team=core
+APP_SERVERS_0_HOST=s0.local
+APP_SERVERS_0_PORT=80
+APP_SERVERS_1_HOST=s1.local
+APP_SERVERS_1_PORT=81
+APP_PRIMARY_HOST=primary.local
+APP_PRIMARY_PORT=5432
+APP_PASSWORD=******
+APP_DB_HOST=db.local
+APP_DB_PORT=5432
+`, string(data))
+	})
+
+	t.Run("should round-trip through the engine of the format", func(t *testing.T) {
+		for format, newEngine := range map[DumpFormat]func(data []byte) Engine{
+			DumpYAML: func(data []byte) Engine { return NewYAMLEngine(NewBytesLoader(data)) },
+			DumpJSON: func(data []byte) Engine { return NewJSONEngine(NewBytesLoader(data)) },
+			DumpEnv:  func(data []byte) Engine { return NewDotenvEngine(NewBytesLoader(data)) },
+		} {
+			t.Run(string(format), func(t *testing.T) {
+				cfg := newTestConfigToDump()
+				data, err := NewManager().Dump(&cfg, format)
+				require.NoError(t, err)
+				assert.NotContains(t, string(data), "s3cr3t")
+
+				manager := NewManager()
+				manager.AddPlainEngine(newEngine(data))
+				manager.AddSecretEngine(newEngine(data))
+
+				var got MyTestConfigToDump
+				require.NoError(t, manager.Populate(&got))
+				cfg.Password = SecretMask
+				assert.Equal(t, cfg, got)
+			})
+		}
+	})
+
+	t.Run("should fail on unsupported formats", func(t *testing.T) {
+		cfg := newTestConfigToDump()
+		_, err := NewManager().Dump(&cfg, DumpFormat("xml"))
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
@@ -31,3 +31,27 @@ type Engine interface {
 
 	GetDuration(key string) (time.Duration, error)
 }
+
+// TimeGetter is implemented by engines that can hold native time.Time values, like YAML timestamps or TOML
+// datetimes. Manager uses it to populate time.Time fields whose values are not strings.
+type TimeGetter interface {
+	GetTime(key string) (time.Time, error)
+}
+
+// KeyLister is implemented by engines that can enumerate the keys they hold. Manager uses it to discover the entries
+// of map fields.
+type KeyLister interface {
+	Keys() []string
+}
+
+// FileSource is implemented by engines reading from files, like the ones created with a FileLoader. Watch uses it to
+// know which files to watch.
+type FileSource interface {
+	Files() []string
+}
+
+// SourceDescriber is implemented by engines that can describe where they read a key from, like `env:APP_DB_HOST` or
+// `yaml:/etc/app.yaml`. Manager.PopulateWithReport uses it to name the engines in its report.
+type SourceDescriber interface {
+	DescribeSource(key string) string
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DirEngine reads configuration from a directory where each file name is a key and the file content is its value,
+// like the volumes Kubernetes creates for ConfigMaps and Secrets or the Docker secrets mounted at `/run/secrets`.
+//
+// Trailing newlines are removed from the values. Entries whose names start with `..` are ignored, which skips the
+// `..data` indirection Kubernetes uses to swap the volume content atomically while still following the symlinks that
+// point into it.
+type DirEngine struct {
+	*stringMapEngine
+	dir       string
+	recursive bool
+	keyMapper func(name string) string
+}
+
+type DirOption func(engine *DirEngine)
+
+// WithRecursive makes the DirEngine descend into sub-directories, mapping their names to the parts of a dotted key:
+// the file `db/host` is read as `db.host`.
+func WithRecursive() DirOption {
+	return func(engine *DirEngine) {
+		engine.recursive = true
+	}
+}
+
+// WithKeyMapper sets the function used to map the file names, relative to the directory and dotted when
+// WithRecursive is used, into the config keys they are read as.
+func WithKeyMapper(keyMapper func(name string) string) DirOption {
+	return func(engine *DirEngine) {
+		engine.keyMapper = keyMapper
+	}
+}
+
+// NewDirEngine returns a new DirEngine that reads the files from the given directory.
+func NewDirEngine(dir string, opts ...DirOption) *DirEngine {
+	engine := &DirEngine{dir: dir}
+	for _, opt := range opts {
+		opt(engine)
+	}
+	return engine
+}
+
+// Load reads all the files of the directory set on the NewDirEngine.
+func (engine *DirEngine) Load() error {
+	values := make(map[string]string)
+	if err := engine.readDir(engine.dir, "", values); err != nil {
+		return err
+	}
+	engine.stringMapEngine = newStringMapEngine(values, nil)
+	return nil
+}
+
+func (engine *DirEngine) readDir(dir, keyPrefix string, values map[string]string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "..") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		// os.Stat follows symlinks, so the files linked into the `..data` directory are read as regular files.
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.IsDir():
+			if !engine.recursive {
+				continue
+			}
+			if err := engine.readDir(path, keyPrefix+name+".", values); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			key := keyPrefix + name
+			if engine.keyMapper != nil {
+				key = engine.keyMapper(key)
+			}
+			values[key] = strings.TrimRight(string(content), "\r\n")
+		}
+	}
+	return nil
+}
+
+// DescribeSource describes the source of the keys as `dir:path`.
+func (engine *DirEngine) DescribeSource(string) string {
+	return "dir:" + engine.dir
+}
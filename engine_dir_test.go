@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestNewDirEngine(t *testing.T) {
+	e := NewDirEngine(t.TempDir())
+	var engine Engine
+	assert.Implements(t, &engine, e)
+}
+
+func TestDirEngine_Load(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "db.host"), "localhost\n")
+	writeTestFile(t, filepath.Join(dir, "db_password"), "s3cr3t\r\n")
+	writeTestFile(t, filepath.Join(dir, "db.port"), "5432")
+	writeTestFile(t, filepath.Join(dir, "hosts"), "a,b,c\n")
+	writeTestFile(t, filepath.Join(dir, "nested", "key"), "nested value\n")
+
+	t.Run("reads files as keys", func(t *testing.T) {
+		dirEngine := NewDirEngine(dir)
+		require.NoError(t, dirEngine.Load())
+
+		host, err := dirEngine.GetString("db.host")
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", host)
+
+		password, err := dirEngine.GetString("db_password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", password)
+
+		port, err := dirEngine.GetInt("db.port")
+		require.NoError(t, err)
+		assert.Equal(t, 5432, port)
+
+		hosts, err := dirEngine.GetStringSlice("hosts")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, hosts)
+	})
+
+	t.Run("ignores sub-directories by default", func(t *testing.T) {
+		dirEngine := NewDirEngine(dir)
+		require.NoError(t, dirEngine.Load())
+
+		_, err := dirEngine.GetString("nested.key")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("maps sub-directories to dotted keys when recursive", func(t *testing.T) {
+		dirEngine := NewDirEngine(dir, WithRecursive())
+		require.NoError(t, dirEngine.Load())
+
+		value, err := dirEngine.GetString("nested.key")
+		require.NoError(t, err)
+		assert.Equal(t, "nested value", value)
+	})
+
+	t.Run("fails when the directory does not exist", func(t *testing.T) {
+		dirEngine := NewDirEngine(filepath.Join(dir, "missing"))
+		require.ErrorIs(t, dirEngine.Load(), os.ErrNotExist)
+	})
+}
+
+func TestDirEngine_LoadKubernetesLayout(t *testing.T) {
+	// Kubernetes writes the files into a timestamped directory, points `..data` to it and links every key to
+	// `..data/<key>`.
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "..2024_01_01_00_00_00.000000001", "db.host"), "k8s-host\n")
+	require.NoError(t, os.Symlink("..2024_01_01_00_00_00.000000001", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "db.host"), filepath.Join(dir, "db.host")))
+
+	dirEngine := NewDirEngine(dir, WithRecursive())
+	require.NoError(t, dirEngine.Load())
+
+	value, err := dirEngine.GetString("db.host")
+	require.NoError(t, err)
+	assert.Equal(t, "k8s-host", value)
+
+	assert.Equal(t, map[string]string{"db.host": "k8s-host"}, dirEngine.data)
+}
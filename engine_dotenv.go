@@ -0,0 +1,262 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DotenvEngine reads configuration from a dotenv (.env) file. Keys are resolved the same way EnvEngine resolves them:
+// `foo.bar` is read from `FOO_BAR`, honoring the prefix given by WithPrefix.
+type DotenvEngine struct {
+	*stringMapEngine
+	loader Loader
+	env    EnvEngine
+}
+
+// NewDotenvEngine returns a new DotenvEngine that reads the dotenv file provided by the given loader.
+func NewDotenvEngine(loader Loader, opts ...EnvOption) *DotenvEngine {
+	return &DotenvEngine{nil, loader, NewEnvEngine(opts...)}
+}
+
+// Load reads and parses the dotenv file provided by the loader set on the NewDotenvEngine.
+//
+// The supported syntax is:
+//   - `KEY=value` pairs, optionally prefixed by `export`;
+//   - comments, starting with `#`, on their own lines or after the values;
+//   - single quoted values, which are kept verbatim;
+//   - double quoted values, which support the `\n`, `\r`, `\t`, `\"`, `\\` and `\$` escapes;
+//   - quoted values spanning multiple lines;
+//   - `${VAR}` references in unquoted and double quoted values, resolved against the variables previously defined in
+//     the file and then against the process environment.
+func (engine *DotenvEngine) Load() error {
+	reader, err := engine.loader.Load()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = engine.loader.Unload()
+	}()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	values, err := parseDotenv(string(data))
+	if err != nil {
+		return err
+	}
+
+	engine.stringMapEngine = newStringMapEngine(values, engine.env.getKey)
+	return nil
+}
+
+type dotenvParser struct {
+	src    string
+	pos    int
+	line   int
+	values map[string]string
+}
+
+func parseDotenv(src string) (map[string]string, error) {
+	p := &dotenvParser{
+		src:    src,
+		line:   1,
+		values: make(map[string]string),
+	}
+	for {
+		p.skipBlanks()
+		if p.eof() {
+			return p.values, nil
+		}
+		switch p.src[p.pos] {
+		case '\n':
+			p.pos++
+			p.line++
+		case '#':
+			p.skipLine()
+		default:
+			if err := p.parseAssignment(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) skipBlanks() {
+	for !p.eof() && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) skipLine() {
+	for !p.eof() && p.src[p.pos] != '\n' {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) errorf(err error) error {
+	return fmt.Errorf("dotenv: line %d: %w", p.line, err)
+}
+
+func (p *dotenvParser) parseAssignment() error {
+	end := strings.IndexAny(p.src[p.pos:], "=\n")
+	if end == -1 || p.src[p.pos+end] != '=' {
+		return p.errorf(ErrInvalidLine)
+	}
+	key := strings.TrimSpace(p.src[p.pos : p.pos+end])
+	if rest, ok := strings.CutPrefix(key, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+		key = strings.TrimSpace(rest)
+	}
+	if key == "" || strings.ContainsAny(key, " \t") {
+		return p.errorf(ErrInvalidLine)
+	}
+	p.pos += end + 1
+	p.skipBlanks()
+
+	var (
+		value  string
+		quoted bool
+		err    error
+	)
+	switch {
+	case p.eof():
+	case p.src[p.pos] == '\'':
+		quoted = true
+		value, err = p.parseSingleQuoted()
+	case p.src[p.pos] == '"':
+		quoted = true
+		value, err = p.parseDoubleQuoted()
+	default:
+		value = p.parseUnquoted()
+	}
+	if err != nil {
+		return err
+	}
+
+	if quoted {
+		// Only a comment is allowed after a quoted value.
+		p.skipBlanks()
+		if !p.eof() && p.src[p.pos] != '\n' && p.src[p.pos] != '#' {
+			return p.errorf(ErrInvalidLine)
+		}
+		p.skipLine()
+	}
+
+	p.values[key] = value
+	return nil
+}
+
+func (p *dotenvParser) parseSingleQuoted() (string, error) {
+	startLine := p.line
+	p.pos++ // Opening quote.
+	end := strings.IndexByte(p.src[p.pos:], '\'')
+	if end == -1 {
+		p.line = startLine
+		return "", p.errorf(ErrUnterminatedString)
+	}
+	value := p.src[p.pos : p.pos+end]
+	p.line += strings.Count(value, "\n")
+	p.pos += end + 1
+	return value, nil
+}
+
+func (p *dotenvParser) parseDoubleQuoted() (string, error) {
+	startLine := p.line
+	p.pos++ // Opening quote.
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.src[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return sb.String(), nil
+		case c == '\\' && p.pos+1 < len(p.src):
+			p.pos++
+			switch e := p.src[p.pos]; e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\', '$':
+				sb.WriteByte(e)
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(e)
+			}
+			p.pos++
+		case c == '$' && strings.HasPrefix(p.src[p.pos:], "${"):
+			p.pos += p.expandReference(&sb, p.src[p.pos:])
+		default:
+			if c == '\n' {
+				p.line++
+			}
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	p.line = startLine
+	return "", p.errorf(ErrUnterminatedString)
+}
+
+func (p *dotenvParser) parseUnquoted() string {
+	end := strings.IndexByte(p.src[p.pos:], '\n')
+	if end == -1 {
+		end = len(p.src) - p.pos
+	}
+	raw := p.src[p.pos : p.pos+end]
+	p.pos += end
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			raw = raw[:i]
+			break
+		}
+	}
+	raw = strings.TrimSpace(raw)
+
+	var sb strings.Builder
+	for i := 0; i < len(raw); {
+		if strings.HasPrefix(raw[i:], "${") {
+			i += p.expandReference(&sb, raw[i:])
+			continue
+		}
+		sb.WriteByte(raw[i])
+		i++
+	}
+	return sb.String()
+}
+
+// expandReference writes the value of the `${VAR}` reference at the beginning of s into sb, returning how many bytes
+// of s were consumed. References without the closing brace are written as they are.
+func (p *dotenvParser) expandReference(sb *strings.Builder, s string) int {
+	end := strings.IndexAny(s, "}\n")
+	if end == -1 || s[end] != '}' {
+		sb.WriteString("${")
+		return 2
+	}
+	name := s[2:end]
+	if value, ok := p.values[name]; ok {
+		sb.WriteString(value)
+	} else {
+		sb.WriteString(os.Getenv(name))
+	}
+	return end + 1
+}
+
+// Files returns the file read by the engine, when its loader is a FileLoader.
+func (engine *DotenvEngine) Files() []string {
+	return loaderFiles(engine.loader)
+}
+
+// DescribeSource describes the source of the keys as `dotenv:path`.
+func (engine *DotenvEngine) DescribeSource(string) string {
+	return describeLoader("dotenv", engine.loader)
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDotenvEngine(t *testing.T) {
+	e := NewDotenvEngine(NewBytesLoader(nil))
+	var engine Engine
+	assert.Implements(t, &engine, e)
+}
+
+func TestDotenvEngine_Load(t *testing.T) {
+	withEnvironment(map[string]string{
+		"DOTENV_TEST_HOME": "/home/test",
+	}, func() {
+		dotenvEngine := NewDotenvEngine(NewBytesLoader([]byte(`# Comment line
+STRING=value
+export EXPORTED=exported value
+SPACED = spaced value # inline comment
+HASH=value#not-a-comment
+SINGLE='single ${STRING} \n'
+DOUBLE="double ${STRING}\t\"quoted\" \${STRING}" # comment
+MULTI="line 1
+line 2"
+MULTI_SINGLE='line 1
+line 2'
+REFERENCE=${STRING}/${DOTENV_TEST_HOME}/${MISSING}
+EMPTY=
+INT=42
+INT_SLICE=1, 2,3
+BOOL=true
+FLOAT=1.5
+DURATION=10s
+NESTED_KEY=nested
+`)))
+		require.NoError(t, dotenvEngine.Load())
+
+		tests := []struct {
+			key  string
+			want string
+		}{
+			{"string", "value"},
+			{"exported", "exported value"},
+			{"spaced", "spaced value"},
+			{"hash", "value#not-a-comment"},
+			{"single", `single ${STRING} \n`},
+			{"double", "double value\t\"quoted\" ${STRING}"},
+			{"multi", "line 1\nline 2"},
+			{"multi_single", "line 1\nline 2"},
+			{"reference", "value//home/test/"},
+			{"empty", ""},
+			{"nested.key", "nested"},
+		}
+		for _, tt := range tests {
+			t.Run("get string "+tt.key, func(t *testing.T) {
+				value, err := dotenvEngine.GetString(tt.key)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, value)
+			})
+		}
+
+		t.Run("get typed values", func(t *testing.T) {
+			i, err := dotenvEngine.GetInt("int")
+			require.NoError(t, err)
+			assert.Equal(t, 42, i)
+
+			is, err := dotenvEngine.GetIntSlice("int_slice")
+			require.NoError(t, err)
+			assert.Equal(t, []int{1, 2, 3}, is)
+
+			b, err := dotenvEngine.GetBool("bool")
+			require.NoError(t, err)
+			assert.True(t, b)
+
+			f, err := dotenvEngine.GetFloat("float")
+			require.NoError(t, err)
+			assert.Equal(t, 1.5, f)
+
+			d, err := dotenvEngine.GetDuration("duration")
+			require.NoError(t, err)
+			assert.Equal(t, 10*time.Second, d)
+		})
+
+		t.Run("when key does not exist", func(t *testing.T) {
+			_, err := dotenvEngine.GetString("missing")
+			require.ErrorIs(t, err, ErrKeyNotFound)
+		})
+	})
+}
+
+func TestDotenvEngine_WithPrefix(t *testing.T) {
+	dotenvEngine := NewDotenvEngine(NewBytesLoader([]byte("APP_DB_HOST=localhost\n")), WithPrefix("app_"))
+	require.NoError(t, dotenvEngine.Load())
+
+	value, err := dotenvEngine.GetString("db.host")
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", value)
+}
+
+func TestDotenvEngine_NotLoaded(t *testing.T) {
+	dotenvEngine := NewDotenvEngine(NewBytesLoader(nil))
+	_, err := dotenvEngine.GetString("key")
+	require.ErrorIs(t, err, ErrEngineNotLoaded)
+	assert.NoError(t, dotenvEngine.Unload())
+}
+
+func TestDotenvEngine_LoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"unterminated double quote", "A=1\nB=\"value\n", ErrUnterminatedString},
+		{"unterminated single quote", "A='value", ErrUnterminatedString},
+		{"missing equal sign", "A\n", ErrInvalidLine},
+		{"key with spaces", "A B=1\n", ErrInvalidLine},
+		{"content after quoted value", "A=\"value\" trailing\n", ErrInvalidLine},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dotenvEngine := NewDotenvEngine(NewBytesLoader([]byte(tt.content)))
+			require.ErrorIs(t, dotenvEngine.Load(), tt.wantErr)
+		})
+	}
+}
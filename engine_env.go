@@ -41,6 +41,7 @@ func (e *EnvEngine) Unload() error {
 	return nil
 }
 
+// getKey maps a config key into the name of the environment variable it is read from: `foo.bar` becomes `FOO_BAR`.
 func (e *EnvEngine) getKey(key string) string {
 	return strings.ToUpper(strings.ReplaceAll(e.prefix+key, ".", "_"))
 }
@@ -238,5 +239,10 @@ func (e *EnvEngine) GetDuration(key string) (time.Duration, error) {
 	if !ok {
 		return 0, ErrKeyNotFound
 	}
-	return time.ParseDuration(value)
+	return parseDuration(value)
+}
+
+// DescribeSource describes the source of key as `env:NAME`, NAME being the environment variable it is read from.
+func (e *EnvEngine) DescribeSource(key string) string {
+	return "env:" + e.getKey(key)
 }
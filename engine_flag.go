@@ -0,0 +1,212 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FlagEngine reads configuration from command line flags. The flags are derived from the `config` tags of a config
+// struct: each key becomes a flag with the same name, so the field tagged `config:"host"` of a struct tagged
+// `config:"db"` is set by `--db.host`. The keys are joined by the key separator of the Manager the engine is added to.
+// The structs that are already being registered, as in recursive structs, are left out: a `next` field of the type of
+// its own struct gets no flags.
+//
+// Only the flags explicitly set in the command line are reported by the getters, so the Manager falls through to the
+// next engines for everything else. Slices can be set either as a comma-separated list or by repeating the flag.
+type FlagEngine struct {
+	*stringMapEngine
+	flagSet    *flag.FlagSet
+	args       []string
+	configType reflect.Type
+	values     map[string]*flagValue
+	// manager is the Manager the engine is added to, whose key separator joins the keys.
+	manager *Manager
+}
+
+type FlagOption func(engine *FlagEngine)
+
+// WithFlagSet sets the flag.FlagSet in which the flags are registered. By default, a new flag.FlagSet named after
+// the program is used.
+func WithFlagSet(flagSet *flag.FlagSet) FlagOption {
+	return func(engine *FlagEngine) {
+		engine.flagSet = flagSet
+	}
+}
+
+// WithArgs sets the arguments parsed by the FlagEngine, without the program name. By default, os.Args[1:] is used.
+func WithArgs(args []string) FlagOption {
+	return func(engine *FlagEngine) {
+		engine.args = args
+	}
+}
+
+// NewFlagEngine returns a new FlagEngine with the flags derived from the given config, which should be the struct (or
+// a pointer to the struct) that will be populated by the Manager.
+func NewFlagEngine(cfg interface{}, opts ...FlagOption) *FlagEngine {
+	engine := &FlagEngine{
+		args:       os.Args[1:],
+		configType: reflect.TypeOf(cfg),
+	}
+	for _, opt := range opts {
+		opt(engine)
+	}
+	if engine.flagSet == nil {
+		engine.flagSet = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	}
+	return engine
+}
+
+// FlagSet returns the flag.FlagSet in which the flags are registered. The flags are only registered by Load.
+func (engine *FlagEngine) FlagSet() *flag.FlagSet {
+	return engine.flagSet
+}
+
+// Load registers the flags (only the first time it is called) and parses the arguments.
+func (engine *FlagEngine) Load() error {
+	if engine.values == nil {
+		engine.values = make(map[string]*flagValue)
+		t := engine.configType
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("flags: %s is not a struct", engine.configType)
+		}
+		engine.registerFields(t, "", nil)
+	}
+
+	for _, value := range engine.values {
+		value.reset()
+	}
+	if err := engine.flagSet.Parse(engine.args); err != nil {
+		return err
+	}
+
+	data := make(map[string]string)
+	for key, value := range engine.values {
+		if value.set {
+			data[key] = value.value
+		}
+	}
+	engine.stringMapEngine = newStringMapEngine(data, nil)
+	return nil
+}
+
+func (engine *FlagEngine) setManager(m *Manager) {
+	engine.manager = m
+}
+
+// keySeparator returns the key separator of the Manager of the engine, or the default one when it was not added to a
+// Manager.
+func (engine *FlagEngine) keySeparator() string {
+	if engine.manager == nil {
+		return defaultKeySeparator
+	}
+	return engine.manager.keySeparator
+}
+
+// decoders returns the decoders registered in the Manager of the engine, whose types are read from a single flag.
+func (engine *FlagEngine) decoders() decoderRegistry {
+	if engine.manager == nil {
+		return nil
+	}
+	return engine.manager.decoders
+}
+
+// registerFields registers the flags of the fields of the struct t. The stack holds the structs being registered.
+func (engine *FlagEngine) registerFields(t reflect.Type, keyPrefix string, stack []reflect.Type) {
+	stack = append(stack, t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := parseFieldTag(field)
+		if !ok {
+			continue
+		}
+		key := keyPrefix + tag.name
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		decoders := engine.decoders()
+		switch {
+		case decoders.isStructType(fieldType):
+			if !slices.Contains(stack, fieldType) {
+				engine.registerFields(fieldType, key+engine.keySeparator(), stack)
+			}
+			continue
+		case fieldType.Kind() == reflect.Slice && decoders.isStructType(derefType(fieldType.Elem())):
+			// Lists of structs cannot be expressed as flags.
+			continue
+		}
+
+		value := &flagValue{
+			isBool:  fieldType.Kind() == reflect.Bool,
+			isSlice: fieldType.Kind() == reflect.Slice && !decoders.has(fieldType),
+		}
+		engine.values[key] = value
+		engine.flagSet.Var(value, key, flagUsage(key, field.Type, tag))
+	}
+}
+
+// flagUsage builds the usage message of the flag for the given key. The type is quoted with back quotes so
+// flag.PrintDefaults uses it as the name of the flag argument.
+func flagUsage(key string, t reflect.Type, tag fieldTag) string {
+	usage := fmt.Sprintf("`%s` value for the %q config key", t, key)
+	attrs := make([]string, 0, 3)
+	if tag.required {
+		attrs = append(attrs, "required")
+	}
+	if tag.secret {
+		attrs = append(attrs, "secret")
+	} else if tag.hasDefault {
+		attrs = append(attrs, fmt.Sprintf("default %q", tag.defaultValue))
+	}
+	if len(attrs) > 0 {
+		usage += " (" + strings.Join(attrs, ", ") + ")"
+	}
+	return usage
+}
+
+// flagValue implements flag.Value storing the raw value of the flag and whether it was set.
+type flagValue struct {
+	value   string
+	set     bool
+	isBool  bool
+	isSlice bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.value
+}
+
+func (v *flagValue) Set(value string) error {
+	if v.isSlice && v.set {
+		value = v.value + "," + value
+	}
+	v.value = value
+	v.set = true
+	return nil
+}
+
+// IsBoolFlag allows boolean flags to be set without a value (`--debug`).
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+func (v *flagValue) reset() {
+	v.value = ""
+	v.set = false
+}
+
+// DescribeSource describes the source of key as `flag:--key`.
+func (engine *FlagEngine) DescribeSource(key string) string {
+	return "flag:--" + key
+}
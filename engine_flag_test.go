@@ -0,0 +1,164 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestFlagConfig struct {
+	Database MyTestWithNestedConfigDatabase `config:"db"`
+	Password string                         `config:"password,required,secret"`
+	Debug    bool                           `config:"debug"`
+	Hosts    []string                       `config:"hosts" default:"localhost"`
+	Ignored  string                         `config:"-"`
+}
+
+func newTestFlagEngine(args ...string) *FlagEngine {
+	return NewFlagEngine(MyTestFlagConfig{}, WithFlagSet(flag.NewFlagSet("test", flag.ContinueOnError)), WithArgs(args))
+}
+
+func TestNewFlagEngine(t *testing.T) {
+	e := newTestFlagEngine()
+	var engine Engine
+	assert.Implements(t, &engine, e)
+}
+
+func TestFlagEngine_Load(t *testing.T) {
+	t.Run("registers the flags from the config keys", func(t *testing.T) {
+		flagEngine := newTestFlagEngine()
+		require.NoError(t, flagEngine.Load())
+
+		for _, name := range []string{"db.dsn", "db.timeout", "password", "debug", "hosts"} {
+			assert.NotNil(t, flagEngine.FlagSet().Lookup(name), name)
+		}
+		assert.Nil(t, flagEngine.FlagSet().Lookup("Ignored"))
+	})
+
+	t.Run("reports only the flags that were set", func(t *testing.T) {
+		flagEngine := newTestFlagEngine("--db.dsn", "postgres://localhost", "-debug", "--db.timeout=5s")
+		require.NoError(t, flagEngine.Load())
+
+		dsn, err := flagEngine.GetString("db.dsn")
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://localhost", dsn)
+
+		debug, err := flagEngine.GetBool("debug")
+		require.NoError(t, err)
+		assert.True(t, debug)
+
+		timeout, err := flagEngine.GetDuration("db.timeout")
+		require.NoError(t, err)
+		assert.Equal(t, 5*time.Second, timeout)
+
+		_, err = flagEngine.GetString("password")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("accepts repeated and comma-separated slices", func(t *testing.T) {
+		flagEngine := newTestFlagEngine("--hosts", "a,b", "--hosts", "c")
+		require.NoError(t, flagEngine.Load())
+
+		hosts, err := flagEngine.GetStringSlice("hosts")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, hosts)
+	})
+
+	t.Run("can be loaded more than once", func(t *testing.T) {
+		flagEngine := newTestFlagEngine("--hosts", "a")
+		require.NoError(t, flagEngine.Load())
+		require.NoError(t, flagEngine.Load())
+
+		hosts, err := flagEngine.GetStringSlice("hosts")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, hosts)
+	})
+
+	t.Run("fails with unknown flags", func(t *testing.T) {
+		flagEngine := newTestFlagEngine("--unknown", "value")
+		flagEngine.FlagSet().SetOutput(&bytes.Buffer{})
+		require.Error(t, flagEngine.Load())
+	})
+
+	t.Run("stops at recursive structs", func(t *testing.T) {
+		var cfg struct {
+			Head *MyTestLinkedNode `config:"head"`
+		}
+		flagEngine := NewFlagEngine(&cfg, WithFlagSet(flag.NewFlagSet("test", flag.ContinueOnError)), WithArgs(nil))
+		require.NoError(t, flagEngine.Load())
+
+		assert.NotNil(t, flagEngine.FlagSet().Lookup("head.name"))
+		assert.Nil(t, flagEngine.FlagSet().Lookup("head.next.name"))
+	})
+
+	t.Run("joins the keys with the key separator of the Manager", func(t *testing.T) {
+		flagEngine := newTestFlagEngine("--db_dsn", "from-flag")
+		manager := NewManager(WithKeySeparator("_"))
+		manager.AddPlainEngine(flagEngine)
+		manager.AddSecretEngine(NewMapEngine(map[string]interface{}{"password": "pass"}))
+
+		var cfg MyTestFlagConfig
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, "from-flag", cfg.Database.DSN)
+		assert.Nil(t, flagEngine.FlagSet().Lookup("db.dsn"))
+	})
+
+	t.Run("reads the types with a decoder of the Manager from a single flag", func(t *testing.T) {
+		flagEngine := NewFlagEngine(MyTestConfigWithMoney{}, WithFlagSet(flag.NewFlagSet("test", flag.ContinueOnError)),
+			WithArgs([]string{"--price", "12.50 USD"}))
+		manager := NewManager(WithDecoder(parseMyTestMoney))
+		manager.AddPlainEngine(flagEngine)
+
+		var cfg MyTestConfigWithMoney
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, MyTestMoney{Cents: 1250, Currency: "USD"}, cfg.Price)
+		assert.Nil(t, flagEngine.FlagSet().Lookup("price.Cents"))
+	})
+
+	t.Run("fails when the config is not a struct", func(t *testing.T) {
+		flagEngine := NewFlagEngine("not a struct", WithArgs(nil))
+		require.Error(t, flagEngine.Load())
+	})
+}
+
+func TestFlagEngine_Usage(t *testing.T) {
+	flagEngine := newTestFlagEngine()
+	require.NoError(t, flagEngine.Load())
+
+	var output bytes.Buffer
+	flagEngine.FlagSet().SetOutput(&output)
+	flagEngine.FlagSet().PrintDefaults()
+
+	assert.Contains(t, output.String(), "-db.timeout time.Duration")
+	assert.Contains(t, output.String(), `value for the "db.timeout" config key`)
+	assert.Contains(t, output.String(), `value for the "db.dsn" config key (required)`)
+	assert.Contains(t, output.String(), `value for the "password" config key (required, secret)`)
+	assert.Contains(t, output.String(), `value for the "hosts" config key (default "localhost")`)
+}
+
+func TestFlagEngine_Populate(t *testing.T) {
+	manager := NewManager()
+	manager.AddPlainEngine(
+		newTestFlagEngine("--db.dsn", "from-flag"),
+		NewMapEngine(map[string]interface{}{
+			"db": map[string]interface{}{
+				"dsn":     "from-map",
+				"timeout": time.Second,
+			},
+			"password": "map-pass",
+		}),
+	)
+	manager.AddSecretEngine(NewMapEngine(map[string]interface{}{
+		"password": "map-pass",
+	}))
+
+	var cfg MyTestFlagConfig
+	require.NoError(t, manager.Populate(&cfg))
+	assert.Equal(t, "from-flag", cfg.Database.DSN)
+	assert.Equal(t, time.Second, cfg.Database.Timeout)
+	assert.Equal(t, "map-pass", cfg.Password)
+}
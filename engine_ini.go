@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// INIEngine reads configuration from an INI file. Keys defined under a `[section]` are read as `section.key`, and
+// keys defined before the first section are read as they are.
+type INIEngine struct {
+	*stringMapEngine
+	loader Loader
+}
+
+// NewINIEngine returns a new INIEngine that reads the INI file provided by the given loader.
+func NewINIEngine(loader Loader) *INIEngine {
+	return &INIEngine{nil, loader}
+}
+
+// Load reads and parses the INI file provided by the loader set on the NewINIEngine.
+//
+// Comments start with `;` or `#`, either on their own lines or after unquoted values. Values wrapped in single or
+// double quotes have the quotes removed.
+func (engine *INIEngine) Load() error {
+	reader, err := engine.loader.Load()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = engine.loader.Unload()
+	}()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	values, err := parseINI(string(data))
+	if err != nil {
+		return err
+	}
+
+	engine.stringMapEngine = newStringMapEngine(values, nil)
+	return nil
+}
+
+func parseINI(src string) (map[string]string, error) {
+	values := make(map[string]string)
+	section := ""
+	for i, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "", line[0] == ';', line[0] == '#':
+			continue
+		case line[0] == '[':
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("ini: line %d: %w", i+1, ErrInvalidLine)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, fmt.Errorf("ini: line %d: %w", i+1, ErrInvalidLine)
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("ini: line %d: %w", i+1, ErrInvalidLine)
+		}
+		value, err := parseINIValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("ini: line %d: %w", i+1, err)
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func parseINIValue(value string) (string, error) {
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		end := strings.IndexByte(value[1:], value[0])
+		if end == -1 {
+			return "", ErrUnterminatedString
+		}
+		if rest := strings.TrimSpace(value[end+2:]); rest != "" && rest[0] != ';' && rest[0] != '#' {
+			return "", ErrInvalidLine
+		}
+		return value[1 : end+1], nil
+	}
+	for i := 1; i < len(value); i++ {
+		if (value[i] == ';' || value[i] == '#') && (value[i-1] == ' ' || value[i-1] == '\t') {
+			return strings.TrimSpace(value[:i]), nil
+		}
+	}
+	return value, nil
+}
+
+// Files returns the file read by the engine, when its loader is a FileLoader.
+func (engine *INIEngine) Files() []string {
+	return loaderFiles(engine.loader)
+}
+
+// DescribeSource describes the source of the keys as `ini:path`.
+func (engine *INIEngine) DescribeSource(string) string {
+	return describeLoader("ini", engine.loader)
+}
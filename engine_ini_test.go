@@ -0,0 +1,104 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewINIEngine(t *testing.T) {
+	e := NewINIEngine(NewBytesLoader(nil))
+	var engine Engine
+	assert.Implements(t, &engine, e)
+}
+
+func TestINIEngine_Load(t *testing.T) {
+	iniEngine := NewINIEngine(NewBytesLoader([]byte(`; global settings
+name = app
+debug = true
+
+[database]
+host = localhost ; inline comment
+port = 5432
+dsn = "postgres://user@host/db ; not a comment"
+replicas = a, b,c
+
+# another comment
+[database.pool]
+size=10
+timeout = 1m30s
+weights = 1.5,2
+`)))
+	require.NoError(t, iniEngine.Load())
+
+	t.Run("get string outside of a section", func(t *testing.T) {
+		value, err := iniEngine.GetString("name")
+		require.NoError(t, err)
+		assert.Equal(t, "app", value)
+	})
+
+	t.Run("get string from a section", func(t *testing.T) {
+		value, err := iniEngine.GetString("database.host")
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", value)
+	})
+
+	t.Run("get quoted string", func(t *testing.T) {
+		value, err := iniEngine.GetString("database.dsn")
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://user@host/db ; not a comment", value)
+	})
+
+	t.Run("get typed values", func(t *testing.T) {
+		b, err := iniEngine.GetBool("debug")
+		require.NoError(t, err)
+		assert.True(t, b)
+
+		port, err := iniEngine.GetUint("database.port")
+		require.NoError(t, err)
+		assert.Equal(t, uint(5432), port)
+
+		size, err := iniEngine.GetInt("database.pool.size")
+		require.NoError(t, err)
+		assert.Equal(t, 10, size)
+
+		timeout, err := iniEngine.GetDuration("database.pool.timeout")
+		require.NoError(t, err)
+		assert.Equal(t, "1m30s", timeout.String())
+	})
+
+	t.Run("get slices", func(t *testing.T) {
+		replicas, err := iniEngine.GetStringSlice("database.replicas")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", " b", "c"}, replicas)
+
+		weights, err := iniEngine.GetFloatSlice("database.pool.weights")
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1.5, 2}, weights)
+	})
+
+	t.Run("when key does not exist", func(t *testing.T) {
+		_, err := iniEngine.GetString("host")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestINIEngine_LoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"unclosed section", "[database\n", ErrInvalidLine},
+		{"empty section", "[]\n", ErrInvalidLine},
+		{"missing equal sign", "key\n", ErrInvalidLine},
+		{"unterminated quote", "key = \"value\n", ErrUnterminatedString},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iniEngine := NewINIEngine(NewBytesLoader([]byte(tt.content)))
+			require.ErrorIs(t, iniEngine.Load(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"encoding/json"
+)
+
+type JSONEngine struct {
+	*MapEngine
+	loader Loader
+}
+
+func NewJSONEngine(loader Loader) *JSONEngine {
+	return &JSONEngine{nil, loader}
+}
+
+// Load loads the JSON document provided by the loader set on the NewJSONEngine saving the data into a internal map.
+//
+// Numbers are kept as json.Number so they can be read by any of the numeric getters.
+func (engine *JSONEngine) Load() error {
+	reader, err := engine.loader.Load()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = engine.loader.Unload()
+	}()
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	data := make(map[string]interface{})
+	if err = decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	engine.MapEngine = NewMapEngine(data)
+	return engine.MapEngine.Load()
+}
+
+// Files returns the file read by the engine, when its loader is a FileLoader.
+func (engine *JSONEngine) Files() []string {
+	return loaderFiles(engine.loader)
+}
+
+// DescribeSource describes the source of the keys as `json:path`.
+func (engine *JSONEngine) DescribeSource(string) string {
+	return describeLoader("json", engine.loader)
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONEngine_Load(t *testing.T) {
+	jsonEngine := NewJSONEngine(NewBytesLoader([]byte(`{
+  "string1": "string1",
+  "stringslice": ["string1", "string2", "string3"],
+  "nested": {
+    "string2": "string2",
+    "nested2": {
+      "string3": "string3"
+    }
+  }
+}`)))
+	err := jsonEngine.Load()
+	require.NoError(t, err)
+
+	value, err := jsonEngine.GetString("string1")
+	assert.NoError(t, err)
+	assert.Equal(t, "string1", value)
+
+	valueSlice, err := jsonEngine.GetStringSlice("stringslice")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"string1", "string2", "string3"}, valueSlice)
+
+	valueNested1, err := jsonEngine.GetString("nested.string2")
+	assert.NoError(t, err)
+	assert.Equal(t, "string2", valueNested1)
+
+	valueNested2, err := jsonEngine.GetString("nested.nested2.string3")
+	assert.NoError(t, err)
+	assert.Equal(t, "string3", valueNested2)
+}
+
+func TestJSONEngine_Numbers(t *testing.T) {
+	jsonEngine := NewJSONEngine(NewBytesLoader([]byte(`{
+  "int": 42,
+  "negative": -7,
+  "big": 18446744073709551615,
+  "float": 1.5,
+  "intslice": [1, 2, 3],
+  "floatslice": [1.5, 2]
+}`)))
+	require.NoError(t, jsonEngine.Load())
+
+	t.Run("get int", func(t *testing.T) {
+		value, err := jsonEngine.GetInt("int")
+		assert.NoError(t, err)
+		assert.Equal(t, 42, value)
+	})
+
+	t.Run("get int64", func(t *testing.T) {
+		value, err := jsonEngine.GetInt64("negative")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-7), value)
+	})
+
+	t.Run("get uint", func(t *testing.T) {
+		value, err := jsonEngine.GetUint("int")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), value)
+	})
+
+	t.Run("get uint64", func(t *testing.T) {
+		value, err := jsonEngine.GetUint64("big")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(18446744073709551615), value)
+	})
+
+	t.Run("get float from an integer", func(t *testing.T) {
+		value, err := jsonEngine.GetFloat("int")
+		assert.NoError(t, err)
+		assert.Equal(t, float64(42), value)
+	})
+
+	t.Run("get float", func(t *testing.T) {
+		value, err := jsonEngine.GetFloat("float")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.5, value)
+	})
+
+	t.Run("get int slice", func(t *testing.T) {
+		value, err := jsonEngine.GetIntSlice("intslice")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, value)
+	})
+
+	t.Run("get float slice", func(t *testing.T) {
+		value, err := jsonEngine.GetFloatSlice("floatslice")
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{1.5, 2}, value)
+	})
+
+	t.Run("fail when reading a float as int", func(t *testing.T) {
+		_, err := jsonEngine.GetInt("float")
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+		assert.ErrorIs(t, err, ErrLossyConversion)
+	})
+
+	t.Run("fail when reading a negative number as uint", func(t *testing.T) {
+		_, err := jsonEngine.GetUint64("negative")
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+		assert.ErrorIs(t, err, ErrLossyConversion)
+	})
+}
+
+func TestJSONEngine_LoadInvalid(t *testing.T) {
+	jsonEngine := NewJSONEngine(NewBytesLoader([]byte(`["not", "an", "object"]`)))
+	assert.Error(t, jsonEngine.Load())
+}
@@ -1,25 +1,42 @@
 package config
 
 import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type MapEngine struct {
 	data map[string]interface{}
+	// source keeps the data given to NewMapEngine, so the engine can be loaded again after being unloaded.
+	source map[string]interface{}
+	// nested is the data given to NewMapEngine, before being flattened, in which the map keys holding dots are kept.
+	nested map[string]interface{}
 }
 
 // NewMapEngine returns a new instance of MapEngine with the given data.
 //
 // Internally, it will flatten the data map before storing for future use.
 func NewMapEngine(data map[string]interface{}) *MapEngine {
-	return &MapEngine{flattenMap(data)}
+	flattened := flattenMap(data)
+	return &MapEngine{flattened, flattened, data}
 }
 
+// Load restores the data of an engine that was unloaded.
 func (engine *MapEngine) Load() error {
+	if engine.data == nil {
+		engine.data = engine.source
+	}
 	return nil
 }
 
 func (engine *MapEngine) Unload() error {
+	if engine == nil {
+		return nil
+	}
 	engine.data = nil
 	return nil
 }
@@ -78,7 +95,7 @@ func (engine *MapEngine) GetInt(key string) (int, error) {
 		}
 		return *t, nil
 	}
-	return 0, newErrTypeMismatch(key, value)
+	return convertToInt(key, value)
 }
 
 func (engine *MapEngine) GetIntSlice(key string) ([]int, error) {
@@ -115,7 +132,7 @@ func (engine *MapEngine) GetUint(key string) (uint, error) {
 		}
 		return *t, nil
 	}
-	return 0, newErrTypeMismatch(key, value)
+	return convertToUint(key, value)
 }
 
 func (engine *MapEngine) GetUintSlice(key string) ([]uint, error) {
@@ -152,7 +169,7 @@ func (engine *MapEngine) GetInt64(key string) (int64, error) {
 		}
 		return *t, nil
 	}
-	return 0, newErrTypeMismatch(key, value)
+	return convertToInt64(key, value)
 }
 
 func (engine *MapEngine) GetInt64Slice(key string) ([]int64, error) {
@@ -189,7 +206,7 @@ func (engine *MapEngine) GetUint64(key string) (uint64, error) {
 		}
 		return *t, nil
 	}
-	return 0, newErrTypeMismatch(key, value)
+	return convertToUint64(key, value)
 }
 
 func (engine *MapEngine) GetUint64Slice(key string) ([]uint64, error) {
@@ -263,7 +280,7 @@ func (engine *MapEngine) GetFloat(key string) (float64, error) {
 		}
 		return *t, nil
 	}
-	return 0, newErrTypeMismatch(key, value)
+	return convertToFloat64(key, value)
 }
 
 func (engine *MapEngine) GetFloatSlice(key string) ([]float64, error) {
@@ -293,12 +310,12 @@ func (engine *MapEngine) GetDuration(key string) (time.Duration, error) {
 	}
 	switch t := value.(type) {
 	case string:
-		return time.ParseDuration(t)
+		return parseDuration(t)
 	case *string:
 		if t == nil {
 			return 0, nil
 		}
-		return time.ParseDuration(*t)
+		return parseDuration(*t)
 	case time.Duration:
 		return t, nil
 	case *time.Duration:
@@ -310,6 +327,85 @@ func (engine *MapEngine) GetDuration(key string) (time.Duration, error) {
 	return 0, newErrTypeMismatch(key, value)
 }
 
+func (engine *MapEngine) GetTime(key string) (time.Time, error) {
+	if engine.data == nil {
+		return time.Time{}, ErrEngineNotLoaded
+	}
+	value, ok := engine.data[key]
+	if !ok {
+		return time.Time{}, ErrKeyNotFound
+	}
+	switch t := value.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, nil
+		}
+		return *t, nil
+	case string:
+		return time.Parse(time.RFC3339Nano, t)
+	}
+	return time.Time{}, newErrTypeMismatch(key, value)
+}
+
+// entries returns the entries of the map at key as they are in the data given to NewMapEngine, so the names holding
+// the key separator are kept whole. It returns false when key does not hold a map.
+func (engine *MapEngine) entries(key, separator string) (map[string]interface{}, bool) {
+	if engine == nil || engine.data == nil {
+		return nil, false
+	}
+	value, ok := lookupNested(engine.nested, key, separator)
+	if !ok {
+		return nil, false
+	}
+	entries, ok := value.(map[string]interface{})
+	return entries, ok
+}
+
+// lookupNested returns the value at key in the nested maps and lists of data. As the names in the maps can hold the
+// separator themselves, each of the names key can start with is tried.
+func lookupNested(data interface{}, key, separator string) (interface{}, bool) {
+	switch data := data.(type) {
+	case map[string]interface{}:
+		if value, ok := data[key]; ok {
+			return value, true
+		}
+		for i := strings.Index(key, separator); i >= 0; {
+			if value, ok := data[key[:i]]; ok {
+				if value, ok := lookupNested(value, key[i+len(separator):], separator); ok {
+					return value, true
+				}
+			}
+			next := strings.Index(key[i+len(separator):], separator)
+			if next < 0 {
+				break
+			}
+			i += len(separator) + next
+		}
+	case []interface{}:
+		head, rest, nested := strings.Cut(key, separator)
+		index, err := strconv.Atoi(head)
+		if err != nil || index < 0 || index >= len(data) {
+			return nil, false
+		}
+		if !nested {
+			return data[index], true
+		}
+		return lookupNested(data[index], rest, separator)
+	}
+	return nil, false
+}
+
+// Keys returns all the keys held by the engine, with nested maps flattened into dotted keys.
+func (engine *MapEngine) Keys() []string {
+	keys := make([]string, 0, len(engine.data))
+	for key := range engine.data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 func flattenMap(data map[string]interface{}) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range data {
@@ -318,6 +414,16 @@ func flattenMap(data map[string]interface{}) map[string]interface{} {
 			for kk, vv := range flattenMap(v) {
 				result[k+"."+kk] = vv
 			}
+		case []interface{}:
+			result[k] = v
+			// Maps inside lists are also flattened, using the index of the element as part of their keys.
+			for i, item := range v {
+				if item, ok := item.(map[string]interface{}); ok {
+					for kk, vv := range flattenMap(item) {
+						result[k+"."+strconv.Itoa(i)+"."+kk] = vv
+					}
+				}
+			}
 		default:
 			result[k] = v
 		}
@@ -353,7 +459,11 @@ func convertInterfaceSliceToIntSlice(key string, data []interface{}) ([]int, err
 				result[i] = *v
 			}
 		default:
-			return nil, newErrTypeMismatch(key, v)
+			n, err := convertToInt(key, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = n
 		}
 	}
 	return result, nil
@@ -370,7 +480,11 @@ func convertInterfaceSliceToUintSlice(key string, data []interface{}) ([]uint, e
 				result[i] = *v
 			}
 		default:
-			return nil, newErrTypeMismatch(key, v)
+			n, err := convertToUint(key, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = n
 		}
 	}
 	return result, nil
@@ -387,7 +501,11 @@ func convertInterfaceSliceToInt64Slice(key string, data []interface{}) ([]int64,
 				result[i] = *v
 			}
 		default:
-			return nil, newErrTypeMismatch(key, v)
+			n, err := convertToInt64(key, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = n
 		}
 	}
 	return result, nil
@@ -404,7 +522,11 @@ func convertInterfaceSliceToUint64Slice(key string, data []interface{}) ([]uint6
 				result[i] = *v
 			}
 		default:
-			return nil, newErrTypeMismatch(key, v)
+			n, err := convertToUint64(key, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = n
 		}
 	}
 	return result, nil
@@ -438,8 +560,158 @@ func convertInterfaceSliceToFloat64Slice(key string, data []interface{}) ([]floa
 				result[i] = *v
 			}
 		default:
-			return nil, newErrTypeMismatch(key, v)
+			n, err := convertToFloat64(key, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = n
 		}
 	}
 	return result, nil
 }
+
+// convertToInt converts a number held by the engine into an int, as convertToInt64 does.
+func convertToInt(key string, value interface{}) (int, error) {
+	n, err := toInt64(key, value, "int")
+	if err != nil {
+		return 0, err
+	}
+	if n < math.MinInt || n > math.MaxInt {
+		return 0, newErrLossyConversion(key, n, "int")
+	}
+	return int(n), nil
+}
+
+// convertToUint converts a number held by the engine into an uint, as convertToUint64 does.
+func convertToUint(key string, value interface{}) (uint, error) {
+	n, err := toUint64(key, value, "uint")
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxUint {
+		return 0, newErrLossyConversion(key, n, "uint")
+	}
+	return uint(n), nil
+}
+
+// convertToInt64 converts a number held by the engine, of any integer or float type or given as a numeric string like
+// a json.Number, into an int64. It fails with ErrLossyConversion when the number is out of range or has a fraction.
+func convertToInt64(key string, value interface{}) (int64, error) {
+	return toInt64(key, value, "int64")
+}
+
+// toInt64 converts value into an int64, as convertToInt64 does, reporting target as the type requested.
+func toInt64(key string, value interface{}, target string) (int64, error) {
+	v, err := numberValue(key, value)
+	if err != nil {
+		return 0, err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := v.Uint(); n <= math.MaxInt64 {
+			return int64(n), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		// float64(math.MaxInt64) rounds up to 2^63, which is out of range.
+		if f := v.Float(); f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), nil
+		}
+	default:
+		return 0, newErrTypeMismatch(key, value)
+	}
+	return 0, newErrLossyConversion(key, v.Interface(), target)
+}
+
+// convertToUint64 converts a number held by the engine into an uint64, as convertToInt64 does.
+func convertToUint64(key string, value interface{}) (uint64, error) {
+	return toUint64(key, value, "uint64")
+}
+
+// toUint64 converts value into an uint64, as convertToUint64 does, reporting target as the type requested.
+func toUint64(key string, value interface{}, target string) (uint64, error) {
+	v, err := numberValue(key, value)
+	if err != nil {
+		return 0, err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := v.Int(); n >= 0 {
+			return uint64(n), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		// float64(math.MaxUint64) rounds up to 2^64, which is out of range.
+		if f := v.Float(); f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 {
+			return uint64(f), nil
+		}
+	default:
+		return 0, newErrTypeMismatch(key, value)
+	}
+	return 0, newErrLossyConversion(key, v.Interface(), target)
+}
+
+// convertToFloat64 converts a number held by the engine into a float64, as convertToInt64 does. Integers that need
+// more than the 53 bits of precision of a float64 fail with ErrLossyConversion, unless they are exactly represented.
+func convertToFloat64(key string, value interface{}) (float64, error) {
+	v, err := numberValue(key, value)
+	if err != nil {
+		return 0, err
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if f := float64(n); f < math.MaxInt64 && int64(f) == n {
+			return f, nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := v.Uint()
+		if f := float64(n); f < math.MaxUint64 && uint64(f) == n {
+			return f, nil
+		}
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	default:
+		return 0, newErrTypeMismatch(key, value)
+	}
+	return 0, newErrLossyConversion(key, v.Interface(), "float64")
+}
+
+// numberValue returns the number held by value, dereferencing pointers and parsing the numeric strings, like the
+// json.Number of the JSONEngine, into an int64, an uint64 or a float64. Values of other types are returned as they
+// are, for the callers to report them.
+func numberValue(key string, value interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem()), nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.String {
+		return v, nil
+	}
+
+	s := v.String()
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return reflect.ValueOf(n), nil
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return reflect.ValueOf(n), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return reflect.Value{}, newErrLossyConversion(key, s, "float64")
+	case err != nil:
+		return reflect.Value{}, newErrTypeMismatch(key, value)
+	}
+	return reflect.ValueOf(f), nil
+}
+
+// DescribeSource describes the source of the keys as `map`.
+func (engine *MapEngine) DescribeSource(string) string {
+	return "map"
+}
@@ -1,10 +1,12 @@
 package config
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_flattenMap(t *testing.T) {
@@ -41,6 +43,25 @@ func Test_flattenMap(t *testing.T) {
 				"c":   true,
 			},
 		},
+		{
+			"receiving a list of maps",
+			map[string]interface{}{
+				"a": []interface{}{
+					map[string]interface{}{
+						"b": 1,
+					},
+					"c",
+				},
+			}, map[string]interface{}{
+				"a": []interface{}{
+					map[string]interface{}{
+						"b": 1,
+					},
+					"c",
+				},
+				"a.0.b": 1,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -61,6 +82,24 @@ func TestMapEngine_Unload(t *testing.T) {
 	})
 	assert.NoError(t, mapEngine.Unload())
 	assert.Nil(t, mapEngine.data)
+
+	_, err := mapEngine.GetInt("123")
+	assert.ErrorIs(t, err, ErrEngineNotLoaded)
+
+	assert.NoError(t, mapEngine.Load())
+	value, err := mapEngine.GetInt("123")
+	assert.NoError(t, err)
+	assert.Equal(t, 456, value)
+}
+
+func TestMapEngine_Keys(t *testing.T) {
+	mapEngine := NewMapEngine(map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": 1,
+		},
+		"c": "c",
+	})
+	assert.ElementsMatch(t, []string{"a.b", "c"}, mapEngine.Keys())
 }
 
 func TestMapEngine_GetString(t *testing.T) {
@@ -624,6 +663,114 @@ func TestMapEngine_GetFloatSlice(t *testing.T) {
 	})
 }
 
+func TestMapEngine_NumericConversion(t *testing.T) {
+	mapEngine := NewMapEngine(map[string]interface{}{
+		"int":         8080,
+		"negative":    -1,
+		"int8":        int8(-8),
+		"uint16":      uint16(16),
+		"float":       8080.0,
+		"fraction":    1.5,
+		"float32":     float32(0.5),
+		"string":      "8080",
+		"floatstring": "1e3",
+		"maxuint64":   uint64(math.MaxUint64),
+		"maxint64":    int64(math.MaxInt64),
+		"bigstring":   "1e400",
+		"intslice":    []interface{}{1, int64(2), uint8(3), 4.0, "5"},
+		"mixedslice":  []interface{}{1, 1.5},
+	})
+
+	t.Run("get an int as every numeric type", func(t *testing.T) {
+		i, err := mapEngine.GetInt("int")
+		require.NoError(t, err)
+		assert.Equal(t, 8080, i)
+		u, err := mapEngine.GetUint("int")
+		require.NoError(t, err)
+		assert.Equal(t, uint(8080), u)
+		i64, err := mapEngine.GetInt64("int")
+		require.NoError(t, err)
+		assert.Equal(t, int64(8080), i64)
+		u64, err := mapEngine.GetUint64("int")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8080), u64)
+		f, err := mapEngine.GetFloat("int")
+		require.NoError(t, err)
+		assert.Equal(t, 8080.0, f)
+	})
+
+	t.Run("get the sized types", func(t *testing.T) {
+		i, err := mapEngine.GetInt("int8")
+		require.NoError(t, err)
+		assert.Equal(t, -8, i)
+		u, err := mapEngine.GetUint64("uint16")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(16), u)
+		f, err := mapEngine.GetFloat("float32")
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, f)
+	})
+
+	t.Run("get a float without fraction as int", func(t *testing.T) {
+		value, err := mapEngine.GetInt("float")
+		require.NoError(t, err)
+		assert.Equal(t, 8080, value)
+	})
+
+	t.Run("get numeric strings", func(t *testing.T) {
+		i, err := mapEngine.GetUint("string")
+		require.NoError(t, err)
+		assert.Equal(t, uint(8080), i)
+		i64, err := mapEngine.GetInt64("floatstring")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), i64)
+	})
+
+	t.Run("get the largest uint64", func(t *testing.T) {
+		value, err := mapEngine.GetUint64("maxuint64")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(math.MaxUint64), value)
+	})
+
+	t.Run("get a slice of mixed numbers", func(t *testing.T) {
+		value, err := mapEngine.GetUintSlice("intslice")
+		require.NoError(t, err)
+		assert.Equal(t, []uint{1, 2, 3, 4, 5}, value)
+		floats, err := mapEngine.GetFloatSlice("intslice")
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 2, 3, 4, 5}, floats)
+	})
+
+	t.Run("fail when the float has a fraction", func(t *testing.T) {
+		_, err := mapEngine.GetInt("fraction")
+		assert.ErrorIs(t, err, ErrLossyConversion)
+		_, err = mapEngine.GetUint64("fraction")
+		assert.ErrorIs(t, err, ErrLossyConversion)
+	})
+
+	t.Run("fail when the number is negative", func(t *testing.T) {
+		_, err := mapEngine.GetUint("negative")
+		assert.ErrorIs(t, err, ErrLossyConversion)
+	})
+
+	t.Run("fail when the number overflows", func(t *testing.T) {
+		_, err := mapEngine.GetInt64("maxuint64")
+		assert.ErrorIs(t, err, ErrLossyConversion)
+		_, err = mapEngine.GetFloat("bigstring")
+		assert.ErrorIs(t, err, ErrLossyConversion)
+	})
+
+	t.Run("fail when the integer loses precision as float", func(t *testing.T) {
+		_, err := mapEngine.GetFloat("maxint64")
+		assert.ErrorIs(t, err, ErrLossyConversion)
+	})
+
+	t.Run("fail when an element loses precision", func(t *testing.T) {
+		_, err := mapEngine.GetIntSlice("mixedslice")
+		assert.ErrorIs(t, err, ErrLossyConversion)
+	})
+}
+
 func TestMapEngine_GetDuration(t *testing.T) {
 	t.Run("not loaded", func(t *testing.T) {
 		m := MapEngine{}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PropertiesEngine reads configuration from a Java .properties file. Dotted property names are used as keys, so
+// `db.host=localhost` is read as `db.host`.
+type PropertiesEngine struct {
+	*stringMapEngine
+	loader Loader
+}
+
+// NewPropertiesEngine returns a new PropertiesEngine that reads the .properties file provided by the given loader.
+func NewPropertiesEngine(loader Loader) *PropertiesEngine {
+	return &PropertiesEngine{nil, loader}
+}
+
+// Load reads and parses the .properties file provided by the loader set on the NewPropertiesEngine.
+//
+// It follows the format described by java.util.Properties: `key=value`, `key: value` and `key value` pairs, comments
+// starting with `#` or `!`, lines continued by a trailing backslash and the `\t`, `\n`, `\r`, `\f` and `\uXXXX`
+// escapes.
+func (engine *PropertiesEngine) Load() error {
+	reader, err := engine.loader.Load()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = engine.loader.Unload()
+	}()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	values, err := parseProperties(string(data))
+	if err != nil {
+		return err
+	}
+
+	engine.stringMapEngine = newStringMapEngine(values, nil)
+	return nil
+}
+
+func parseProperties(src string) (map[string]string, error) {
+	values := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// Join the continuation lines into a single logical line.
+		for endsWithContinuation(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		if endsWithContinuation(line) {
+			line = line[:len(line)-1]
+		}
+
+		key, value := splitPropertiesLine(line)
+		key, err := unescapeProperties(key)
+		if err != nil {
+			return nil, fmt.Errorf("properties: line %d: %w", lineNumber, err)
+		}
+		value, err = unescapeProperties(value)
+		if err != nil {
+			return nil, fmt.Errorf("properties: line %d: %w", lineNumber, err)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// endsWithContinuation reports whether line ends with an odd number of backslashes.
+func endsWithContinuation(line string) bool {
+	n := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// splitPropertiesLine splits a logical line into its raw (still escaped) key and value. The key ends at the first
+// unescaped `=`, `:` or whitespace.
+func splitPropertiesLine(line string) (string, string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '=' || line[i] == ':' || line[i] == ' ' || line[i] == '\t' || line[i] == '\f' {
+			end = i
+			break
+		}
+	}
+	key, rest := line[:end], strings.TrimLeft(line[end:], " \t\f")
+	if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+		rest = strings.TrimLeft(rest[1:], " \t\f")
+	}
+	return key, rest
+}
+
+func unescapeProperties(s string) (string, error) {
+	if !strings.Contains(s, "\\") {
+		return s, nil
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", ErrInvalidLine
+			}
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", ErrInvalidLine
+			}
+			sb.WriteRune(rune(r))
+			i += 4
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// Files returns the file read by the engine, when its loader is a FileLoader.
+func (engine *PropertiesEngine) Files() []string {
+	return loaderFiles(engine.loader)
+}
+
+// DescribeSource describes the source of the keys as `properties:path`.
+func (engine *PropertiesEngine) DescribeSource(string) string {
+	return describeLoader("properties", engine.loader)
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPropertiesEngine(t *testing.T) {
+	e := NewPropertiesEngine(NewBytesLoader(nil))
+	var engine Engine
+	assert.Implements(t, &engine, e)
+}
+
+func TestPropertiesEngine_Load(t *testing.T) {
+	propertiesEngine := NewPropertiesEngine(NewBytesLoader([]byte(`# comment
+! another comment
+db.host=localhost
+db.port: 5432
+db.name   app
+db.hosts = a,b,\
+           c
+greeting = hello\tworld \u00e9
+key\ with\ spaces = value
+path = C:\\temp
+empty =
+`)))
+	require.NoError(t, propertiesEngine.Load())
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"db.host", "localhost"},
+		{"db.port", "5432"},
+		{"db.name", "app"},
+		{"db.hosts", "a,b,c"},
+		{"greeting", "hello\tworld é"},
+		{"key with spaces", "value"},
+		{"path", `C:\temp`},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run("get string "+tt.key, func(t *testing.T) {
+			value, err := propertiesEngine.GetString(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	t.Run("get typed values", func(t *testing.T) {
+		port, err := propertiesEngine.GetInt("db.port")
+		require.NoError(t, err)
+		assert.Equal(t, 5432, port)
+
+		hosts, err := propertiesEngine.GetStringSlice("db.hosts")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, hosts)
+	})
+
+	t.Run("when key does not exist", func(t *testing.T) {
+		_, err := propertiesEngine.GetString("db.user")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestPropertiesEngine_LoadInvalidUnicodeEscape(t *testing.T) {
+	propertiesEngine := NewPropertiesEngine(NewBytesLoader([]byte("key = \\u12\n")))
+	require.ErrorIs(t, propertiesEngine.Load(), ErrInvalidLine)
+}
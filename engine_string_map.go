@@ -0,0 +1,242 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stringMapEngine implements the typed getters of the Engine interface over a map of raw string values, parsing them
+// the same way EnvEngine parses environment variables: slices are comma-separated. It backs the engines whose sources
+// only hold text, like dotenv files.
+type stringMapEngine struct {
+	data map[string]string
+	// keyFunc maps the keys requested by the Manager into the keys of data. When nil, keys are used as they are.
+	keyFunc func(key string) string
+	// source describes where data comes from, see DescribeSource.
+	source string
+}
+
+func newStringMapEngine(data map[string]string, keyFunc func(key string) string) *stringMapEngine {
+	return &stringMapEngine{data: data, keyFunc: keyFunc}
+}
+
+func (engine *stringMapEngine) lookup(key string) (string, error) {
+	if engine == nil || engine.data == nil {
+		return "", ErrEngineNotLoaded
+	}
+	if engine.keyFunc != nil {
+		key = engine.keyFunc(key)
+	}
+	value, ok := engine.data[key]
+	if !ok {
+		return "", ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (engine *stringMapEngine) lookupSlice(key string) ([]string, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, nil
+	}
+	return strings.Split(value, ","), nil
+}
+
+func (engine *stringMapEngine) Load() error {
+	return nil
+}
+
+func (engine *stringMapEngine) Unload() error {
+	if engine == nil {
+		return nil
+	}
+	engine.data = nil
+	return nil
+}
+
+// Keys returns the keys held by the engine. Engines that map the requested keys (see keyFunc) cannot tell which keys
+// they hold, so they do not return any.
+func (engine *stringMapEngine) Keys() []string {
+	if engine == nil || engine.keyFunc != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(engine.data))
+	for key := range engine.data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (engine *stringMapEngine) GetString(key string) (string, error) {
+	return engine.lookup(key)
+}
+
+func (engine *stringMapEngine) GetStringSlice(key string) ([]string, error) {
+	return engine.lookupSlice(key)
+}
+
+func (engine *stringMapEngine) GetInt(key string) (int, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+func (engine *stringMapEngine) GetIntSlice(key string) ([]int, error) {
+	values, err := engine.lookupSlice(key)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	result := make([]int, 0, len(values))
+	for i, v := range values {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func (engine *stringMapEngine) GetUint(key string) (uint, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(value, 10, 32)
+	return uint(v), err
+}
+
+func (engine *stringMapEngine) GetUintSlice(key string) ([]uint, error) {
+	values, err := engine.lookupSlice(key)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	result := make([]uint, 0, len(values))
+	for i, v := range values {
+		u, err := strconv.ParseUint(strings.TrimSpace(v), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, uint(u))
+	}
+	return result, nil
+}
+
+func (engine *stringMapEngine) GetInt64(key string) (int64, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func (engine *stringMapEngine) GetInt64Slice(key string) ([]int64, error) {
+	values, err := engine.lookupSlice(key)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	result := make([]int64, 0, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func (engine *stringMapEngine) GetUint64(key string) (uint64, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func (engine *stringMapEngine) GetUint64Slice(key string) ([]uint64, error) {
+	values, err := engine.lookupSlice(key)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	result := make([]uint64, 0, len(values))
+	for i, v := range values {
+		u, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, u)
+	}
+	return result, nil
+}
+
+func (engine *stringMapEngine) GetBool(key string) (bool, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
+
+func (engine *stringMapEngine) GetBoolSlice(key string) ([]bool, error) {
+	values, err := engine.lookupSlice(key)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	result := make([]bool, 0, len(values))
+	for i, v := range values {
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, b)
+	}
+	return result, nil
+}
+
+func (engine *stringMapEngine) GetFloat(key string) (float64, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func (engine *stringMapEngine) GetFloatSlice(key string) ([]float64, error) {
+	values, err := engine.lookupSlice(key)
+	if err != nil || values == nil {
+		return nil, err
+	}
+	result := make([]float64, 0, len(values))
+	for i, v := range values {
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func (engine *stringMapEngine) GetDuration(key string) (time.Duration, error) {
+	value, err := engine.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	return parseDuration(value)
+}
+
+// DescribeSource describes the source of the keys as set when the engine was created, or as `map`.
+func (engine *stringMapEngine) DescribeSource(string) string {
+	if engine == nil || engine.source == "" {
+		return "map"
+	}
+	return engine.source
+}
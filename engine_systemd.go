@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+const credentialsDirectoryEnv = "CREDENTIALS_DIRECTORY"
+
+// SystemdCredentialsEngine reads the credentials systemd passes to a service through `LoadCredential=`,
+// `SetCredential=` and friends. They are read from the directory systemd sets in the CREDENTIALS_DIRECTORY
+// environment variable, each credential name being a key.
+//
+// It is meant to be used as a secret engine (see Manager.AddSecretEngine). The options are the same accepted by
+// NewDirEngine, WithKeyMapper being the way to map credential names into config keys.
+type SystemdCredentialsEngine struct {
+	*DirEngine
+}
+
+// NewSystemdCredentialsEngine returns a new SystemdCredentialsEngine.
+func NewSystemdCredentialsEngine(opts ...DirOption) *SystemdCredentialsEngine {
+	return &SystemdCredentialsEngine{NewDirEngine("", opts...)}
+}
+
+// Load reads the credentials from the directory set in the CREDENTIALS_DIRECTORY environment variable. It returns
+// ErrCredentialsDirectoryNotSet when the variable is not set.
+func (engine *SystemdCredentialsEngine) Load() error {
+	dir, ok := os.LookupEnv(credentialsDirectoryEnv)
+	if !ok || dir == "" {
+		return ErrCredentialsDirectoryNotSet
+	}
+	engine.dir = dir
+	if err := engine.DirEngine.Load(); err != nil {
+		return fmt.Errorf("systemd credentials: %w", err)
+	}
+	return nil
+}
+
+// DescribeSource describes the source of the keys as `systemdcreds:path`.
+func (engine *SystemdCredentialsEngine) DescribeSource(string) string {
+	return "systemdcreds:" + engine.dir
+}
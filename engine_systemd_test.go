@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSystemdCredentialsEngine(t *testing.T) {
+	e := NewSystemdCredentialsEngine()
+	var engine Engine
+	assert.Implements(t, &engine, e)
+}
+
+func TestSystemdCredentialsEngine_Load(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "db_password"), "s3cr3t\n")
+
+	t.Run("reads the credentials from CREDENTIALS_DIRECTORY", func(t *testing.T) {
+		t.Setenv(credentialsDirectoryEnv, dir)
+
+		engine := NewSystemdCredentialsEngine()
+		require.NoError(t, engine.Load())
+
+		value, err := engine.GetString("db_password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", value)
+	})
+
+	t.Run("maps the credential names using the key mapper", func(t *testing.T) {
+		t.Setenv(credentialsDirectoryEnv, dir)
+
+		engine := NewSystemdCredentialsEngine(WithKeyMapper(func(name string) string {
+			return strings.ReplaceAll(name, "_", ".")
+		}))
+		require.NoError(t, engine.Load())
+
+		value, err := engine.GetString("db.password")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cr3t", value)
+	})
+
+	t.Run("fails when CREDENTIALS_DIRECTORY is not set", func(t *testing.T) {
+		t.Setenv(credentialsDirectoryEnv, "")
+		require.NoError(t, os.Unsetenv(credentialsDirectoryEnv))
+
+		engine := NewSystemdCredentialsEngine()
+		require.ErrorIs(t, engine.Load(), ErrCredentialsDirectoryNotSet)
+	})
+
+	t.Run("fails when CREDENTIALS_DIRECTORY does not exist", func(t *testing.T) {
+		t.Setenv(credentialsDirectoryEnv, filepath.Join(dir, "missing"))
+
+		engine := NewSystemdCredentialsEngine()
+		err := engine.Load()
+		require.ErrorIs(t, err, os.ErrNotExist)
+		assert.Contains(t, err.Error(), "systemd credentials")
+	})
+
+	t.Run("populates secret fields", func(t *testing.T) {
+		writeTestFile(t, filepath.Join(dir, "password"), "systemd-pass\n")
+		t.Setenv(credentialsDirectoryEnv, dir)
+		t.Setenv("CONFIG_LOAD_OPTIONS_TEST", `{"plain":["yamlfile:testdata/config_simple.yaml"],"secrets":["systemdcreds"]}`)
+
+		m := NewManager(WithLoadOptionsEnv("CONFIG_LOAD_OPTIONS_TEST"))
+
+		var cfg MyTestConfig
+		require.NoError(t, m.Populate(&cfg))
+		assert.Equal(t, "systemd-pass", cfg.Password)
+	})
+}
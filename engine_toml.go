@@ -0,0 +1,75 @@
+package config
+
+import (
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+type TOMLEngine struct {
+	*MapEngine
+	loader Loader
+}
+
+func NewTOMLEngine(loader Loader) *TOMLEngine {
+	return &TOMLEngine{nil, loader}
+}
+
+// Load loads the TOML document provided by the loader set on the NewTOMLEngine saving the data into a internal map.
+//
+// Integers are kept as int64, which the numeric getters convert as they do the YAML ones, and local dates and
+// datetimes are converted to time.Time using the local timezone.
+func (engine *TOMLEngine) Load() error {
+	reader, err := engine.loader.Load()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = engine.loader.Unload()
+	}()
+
+	decoder := toml.NewDecoder(reader)
+	data := make(map[string]interface{})
+	if err = decoder.Decode(&data); err != nil {
+		return err
+	}
+
+	engine.MapEngine = NewMapEngine(normalizeTOMLMap(data))
+	return engine.MapEngine.Load()
+}
+
+func normalizeTOMLMap(data map[string]interface{}) map[string]interface{} {
+	for k, v := range data {
+		data[k] = normalizeTOMLValue(v)
+	}
+	return data
+}
+
+func normalizeTOMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return normalizeTOMLMap(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeTOMLValue(v[i])
+		}
+		return v
+	case toml.LocalDateTime:
+		return v.AsTime(time.Local)
+	case toml.LocalDate:
+		return v.AsTime(time.Local)
+	case toml.LocalTime:
+		return v.String()
+	}
+	return value
+}
+
+// Files returns the file read by the engine, when its loader is a FileLoader.
+func (engine *TOMLEngine) Files() []string {
+	return loaderFiles(engine.loader)
+}
+
+// DescribeSource describes the source of the keys as `toml:path`.
+func (engine *TOMLEngine) DescribeSource(string) string {
+	return describeLoader("toml", engine.loader)
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOMLEngine_Load(t *testing.T) {
+	tomlEngine := NewTOMLEngine(NewBytesLoader([]byte(`string1 = "string1"
+stringslice = ["string1", "string2", "string3"]
+int = 42
+intslice = [1, 2, 3]
+float = 1.5
+bool = true
+offset = 2024-03-01T10:00:00Z
+local = 2024-03-01T10:00:00
+date = 2024-03-01
+clock = 07:32:00
+
+[nested]
+string2 = "string2"
+
+[nested.nested2]
+string3 = "string3"
+`)))
+	err := tomlEngine.Load()
+	require.NoError(t, err)
+
+	t.Run("get string", func(t *testing.T) {
+		value, err := tomlEngine.GetString("string1")
+		assert.NoError(t, err)
+		assert.Equal(t, "string1", value)
+	})
+
+	t.Run("get string slice", func(t *testing.T) {
+		value, err := tomlEngine.GetStringSlice("stringslice")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"string1", "string2", "string3"}, value)
+	})
+
+	t.Run("get nested tables", func(t *testing.T) {
+		value, err := tomlEngine.GetString("nested.string2")
+		assert.NoError(t, err)
+		assert.Equal(t, "string2", value)
+
+		value, err = tomlEngine.GetString("nested.nested2.string3")
+		assert.NoError(t, err)
+		assert.Equal(t, "string3", value)
+	})
+
+	t.Run("get integers with every numeric getter", func(t *testing.T) {
+		i, err := tomlEngine.GetInt("int")
+		assert.NoError(t, err)
+		assert.Equal(t, 42, i)
+
+		i64, err := tomlEngine.GetInt64("int")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), i64)
+
+		u, err := tomlEngine.GetUint("int")
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), u)
+
+		u64, err := tomlEngine.GetUint64("int")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), u64)
+
+		f, err := tomlEngine.GetFloat("int")
+		assert.NoError(t, err)
+		assert.Equal(t, float64(42), f)
+	})
+
+	t.Run("get int slice", func(t *testing.T) {
+		value, err := tomlEngine.GetIntSlice("intslice")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, value)
+	})
+
+	t.Run("get float", func(t *testing.T) {
+		value, err := tomlEngine.GetFloat("float")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.5, value)
+	})
+
+	t.Run("get bool", func(t *testing.T) {
+		value, err := tomlEngine.GetBool("bool")
+		assert.NoError(t, err)
+		assert.True(t, value)
+	})
+
+	t.Run("get offset datetime", func(t *testing.T) {
+		value, err := tomlEngine.GetTime("offset")
+		assert.NoError(t, err)
+		assert.True(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC).Equal(value))
+	})
+
+	t.Run("get local datetime", func(t *testing.T) {
+		value, err := tomlEngine.GetTime("local")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local), value)
+	})
+
+	t.Run("get local date", func(t *testing.T) {
+		value, err := tomlEngine.GetTime("date")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), value)
+	})
+
+	t.Run("get local time as string", func(t *testing.T) {
+		value, err := tomlEngine.GetString("clock")
+		assert.NoError(t, err)
+		assert.Equal(t, "07:32:00", value)
+	})
+}
+
+func TestTOMLEngine_LoadInvalid(t *testing.T) {
+	tomlEngine := NewTOMLEngine(NewBytesLoader([]byte(`key = `)))
+	assert.Error(t, tomlEngine.Load())
+}
+
+func TestTOMLEngine_Populate(t *testing.T) {
+	t.Run("reads the integers as the YAML ones", func(t *testing.T) {
+		var cfg struct {
+			Port int64  `config:"port"`
+			URL  string `config:"url"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(NewTOMLEngine(NewBytesLoader([]byte(`port = 8080
+url = "http://localhost:${port}"
+`))))
+
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, int64(8080), cfg.Port)
+		assert.Equal(t, "http://localhost:8080", cfg.URL)
+	})
+}
@@ -32,3 +32,13 @@ func (engine *YAMLEngine) Load() error {
 	engine.MapEngine = NewMapEngine(data)
 	return engine.MapEngine.Load()
 }
+
+// Files returns the file read by the engine, when its loader is a FileLoader.
+func (engine *YAMLEngine) Files() []string {
+	return loaderFiles(engine.loader)
+}
+
+// DescribeSource describes the source of the keys as `yaml:path`.
+func (engine *YAMLEngine) DescribeSource(string) string {
+	return describeLoader("yaml", engine.loader)
+}
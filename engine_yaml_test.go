@@ -37,3 +37,26 @@ nested:
 	assert.NoError(t, err)
 	assert.Equal(t, "string3", valueNested2)
 }
+
+func TestYAMLEngine_Numbers(t *testing.T) {
+	var cfg struct {
+		Port    uint16    `config:"port"`
+		Size    int64     `config:"size"`
+		Max     uint64    `config:"max"`
+		Ratio   float64   `config:"ratio"`
+		Weights []float64 `config:"weights"`
+	}
+	manager := NewManager()
+	manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`port: 8080
+size: 1024
+max: 18446744073709551615
+ratio: 2
+weights: [1, 2.5]
+`))))
+	require.NoError(t, manager.Populate(&cfg))
+	assert.Equal(t, uint16(8080), cfg.Port)
+	assert.Equal(t, int64(1024), cfg.Size)
+	assert.Equal(t, uint64(18446744073709551615), cfg.Max)
+	assert.Equal(t, 2.0, cfg.Ratio)
+	assert.Equal(t, []float64{1, 2.5}, cfg.Weights)
+}
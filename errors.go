@@ -3,6 +3,8 @@ package config
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 )
 
 var (
@@ -12,6 +14,15 @@ var (
 	// ErrTypeMismatch is returned when a type mismatch for Engine get operations.
 	ErrTypeMismatch = errors.New("type mismatch")
 
+	// ErrLossyConversion is returned by the MapEngine getters when a number cannot be converted to the type requested
+	// without loss: it is out of the range of the type, or it has a fraction and the type is an integer. The errors
+	// also wrap ErrTypeMismatch, which they were reported as before.
+	ErrLossyConversion = errors.New("lossy numeric conversion")
+
+	// ErrValueOutOfRange is returned by Manager.Populate when a number read for a field does not fit in its type, like
+	// 300 for an int8.
+	ErrValueOutOfRange = errors.New("value out of range")
+
 	// ErrNoSecretEngineDefined is returned by Manager.Populate when a secret config is defined but there is no secret
 	// engine defined.
 	ErrNoSecretEngineDefined = errors.New("no secret engine defined")
@@ -25,8 +36,116 @@ var (
 
 	// ErrConfigNotPointer is returned by Manager.Populate when the config is not a pointer.
 	ErrConfigNotPointer = errors.New("config not pointer")
+
+	// ErrCredentialsDirectoryNotSet is returned by SystemdCredentialsEngine.Load when the service was not started with
+	// credentials, so systemd did not set the CREDENTIALS_DIRECTORY environment variable.
+	ErrCredentialsDirectoryNotSet = errors.New("CREDENTIALS_DIRECTORY is not set")
+
+	// ErrInvalidMapEntry is returned by Manager.Populate when a map is given as a `k1=v1,k2=v2` string and one of its
+	// entries does not have the `=` separator.
+	ErrInvalidMapEntry = errors.New("invalid map entry")
+
+	// ErrInvalidLine is returned by file based engines when a line of the source cannot be parsed.
+	ErrInvalidLine = errors.New("invalid line")
+
+	// ErrUnresolvedReference is returned by Manager.Populate when a `${key}` reference in a value has no fallback and
+	// its key is not found.
+	ErrUnresolvedReference = errors.New("unresolved reference")
+
+	// ErrReferenceCycle is returned by Manager.Populate when the `${key}` references in the values refer to each other.
+	ErrReferenceCycle = errors.New("reference cycle")
+
+	// ErrInvalidReference is returned by Manager.Populate when a `${` in a value is not closed, or when a field that is
+	// not secret references a secret.
+	ErrInvalidReference = errors.New("invalid reference")
+
+	// ErrUnsupportedFormat is returned by Manager.Dump and Manager.RenderDocs when the format is not one of the DumpFormat
+	// or DocFormat constants.
+	ErrUnsupportedFormat = errors.New("unsupported format")
+
+	// ErrValidationFailed is returned by Manager.Populate when the value of a field breaks a rule of its `validate` tag.
+	ErrValidationFailed = errors.New("validation failed")
+
+	// ErrInvalidValidateTag is returned by Manager.Populate when the `validate` tag of a field has an unknown rule, or a
+	// rule whose parameter cannot be parsed or does not apply to the type of the field.
+	ErrInvalidValidateTag = errors.New("invalid validate tag")
+
+	// ErrInvalidConfigTag is returned by Manager.Populate and JSONSchema when the `config` tag of a field has a `layout=`
+	// option cut by the commas of a layout.
+	ErrInvalidConfigTag = errors.New("invalid config tag")
+
+	// ErrNoFilesToWatch is returned by Watch when none of the engines of the Manager read from files.
+	ErrNoFilesToWatch = errors.New("no files to watch")
 )
 
+// isReferenceError reports whether err is a failure expanding the references of a value.
+func isReferenceError(err error) bool {
+	return errors.Is(err, ErrUnresolvedReference) || errors.Is(err, ErrReferenceCycle) || errors.Is(err, ErrInvalidReference)
+}
+
 func newErrTypeMismatch(key string, value interface{}) error {
 	return fmt.Errorf("%w: %s: %T found", ErrTypeMismatch, key, value)
 }
+
+func newErrValueOutOfRange(key string, value interface{}, t reflect.Type) error {
+	return fmt.Errorf("%w: %s: %v overflows %s", ErrValueOutOfRange, key, value, t)
+}
+
+// newErrInvalidValue names the key of the value that err failed to parse.
+func newErrInvalidValue(key string, err error) error {
+	return fmt.Errorf("%s: %w", key, err)
+}
+
+func newErrLossyConversion(key string, value interface{}, target string) error {
+	return fmt.Errorf("%w: %w: %s: %v to %s", ErrTypeMismatch, ErrLossyConversion, key, value, target)
+}
+
+// FieldError is the failure of a single field of the config, as collected by Manager.Populate when the Manager is
+// created with WithCollectAllErrors.
+type FieldError struct {
+	// Path is the Go path of the field, like `Servers[1].Port`. It is empty for the Validator failures of the config
+	// itself.
+	Path string
+
+	// Key is the config key of the field, like `servers.1.port`.
+	Key string
+
+	// Engine is the engine that failed reading the field or, for missing keys, the last engine tried. It is nil when
+	// the failure does not come from an engine, like the Validator failures.
+	Engine Engine
+
+	// Err is the failure, wrapping ErrKeyNotFound for missing required keys and ErrTypeMismatch for values that
+	// cannot be read into the field.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors is returned by Manager.Populate, when the Manager is created with WithCollectAllErrors, listing all the
+// fields that failed. errors.Is and errors.As match any of its FieldError.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	envReferencePrefix    = "ENV:"
+	secretReferencePrefix = "secret:"
+	referenceFallbackSep  = ":-"
+)
+
+// interpolate returns the engine from which key should be read, once the references in its value are expanded. When
+// the value has no references, the engine itself is returned. The ref is how the key being read is referenced, used
+// to detect cycles.
+//
+// The references are written as `${key}`, resolved against the plain engines, `${secret:key}`, resolved against the
+// secret engines, and `${ENV:NAME}`, resolved against the environment variables. They can have a fallback value,
+// used when the key is not found, as in `${key:-fallback}`, and `$${` is a literal `${`. The secret references are
+// only resolved for the secret fields, so the secrets do not end up in plain fields, which Manager.Dump writes. The
+// secret fields are only interpolated when tagged `interpolate`.
+func (p *populator) interpolate(engine Engine, key, ref string, secret bool, t reflect.Type) (Engine, error) {
+	// The slices with a decoder, like net.IP, are read from a single string.
+	if t.Kind() == reflect.Slice && !p.decoders.has(t) {
+		values, err := engine.GetStringSlice(key)
+		if err != nil || !slices.ContainsFunc(values, hasReferences) {
+			return engine, nil
+		}
+		expanded := make([]string, len(values))
+		for i, value := range values {
+			if expanded[i], err = p.expand(value, []string{ref}, secret); err != nil {
+				return nil, err
+			}
+		}
+		if t.Elem().Kind() == reflect.String {
+			data := make([]interface{}, len(expanded))
+			for i, value := range expanded {
+				data[i] = value
+			}
+			return NewMapEngine(map[string]interface{}{key: data}), nil
+		}
+		return newStringMapEngine(map[string]string{key: strings.Join(expanded, ",")}, nil), nil
+	}
+
+	value, err := engine.GetString(key)
+	if err != nil || !hasReferences(value) {
+		return engine, nil
+	}
+	expanded, err := p.expand(value, []string{ref}, secret)
+	if err != nil {
+		return nil, err
+	}
+	return newStringMapEngine(map[string]string{key: expanded}, nil), nil
+}
+
+func hasReferences(value string) bool {
+	return strings.Contains(value, "${")
+}
+
+// expand replaces the references in s with their values. The stack holds the references being expanded, and secret
+// tells whether the value is read for a secret field.
+func (p *populator) expand(s string, stack []string, secret bool) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "$${"):
+			b.WriteString("${")
+			i += 2
+		case strings.HasPrefix(s[i:], "${"):
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("%w: %q", ErrInvalidReference, s[i:])
+			}
+			value, err := p.resolve(s[i+2:end], stack, secret)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+			i = end
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// closingBrace returns the index of the brace closing the reference starting at start, taking nested references into
+// account, or -1 when it is not closed.
+func closingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// resolve returns the value of the reference expr, which is the content of `${...}`.
+func (p *populator) resolve(expr string, stack []string, secret bool) (string, error) {
+	ref, fallback, hasFallback := strings.Cut(expr, referenceFallbackSep)
+	var (
+		value string
+		found bool
+		err   error
+	)
+	if name, ok := strings.CutPrefix(ref, envReferencePrefix); ok {
+		value, found = os.LookupEnv(name)
+	} else if key, ok := strings.CutPrefix(ref, secretReferencePrefix); ok {
+		if !secret {
+			return "", fmt.Errorf("%w: %s is only allowed in secret fields", ErrInvalidReference, ref)
+		}
+		value, found, err = p.lookupReference(p.secrets, key, ref, stack, secret)
+	} else {
+		value, found, err = p.lookupReference(p.plains, ref, ref, stack, secret)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		if !hasFallback {
+			return "", fmt.Errorf("%w: %s", ErrUnresolvedReference, ref)
+		}
+		return p.expand(fallback, stack, secret)
+	}
+	return value, nil
+}
+
+// lookupReference returns the expanded value of key from the first of the engines that has it.
+func (p *populator) lookupReference(engines []Engine, key, ref string, stack []string, secret bool) (string, bool, error) {
+	if slices.Contains(stack, ref) {
+		return "", false, fmt.Errorf("%w: %s -> %s", ErrReferenceCycle, strings.Join(stack, " -> "), ref)
+	}
+	for _, engine := range engines {
+		raw, err := referenceValue(engine, key)
+		if errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrEngineNotLoaded) {
+			continue
+		} else if err != nil {
+			return "", false, fmt.Errorf("%s: %w", ref, err)
+		}
+		value, err := p.expand(raw, append(stack[:len(stack):len(stack)], ref), secret)
+		return value, true, err
+	}
+	return "", false, nil
+}
+
+// referenceValue reads key from engine as a string, formatting the numbers and booleans of the engines that hold
+// typed values.
+func referenceValue(engine Engine, key string) (string, error) {
+	value, err := engine.GetString(key)
+	if !errors.Is(err, ErrTypeMismatch) {
+		return value, err
+	}
+	if i, err := engine.GetInt64(key); err == nil {
+		return strconv.FormatInt(i, 10), nil
+	}
+	if i, err := engine.GetInt(key); err == nil {
+		return strconv.Itoa(i), nil
+	}
+	if u, err := engine.GetUint64(key); err == nil {
+		return strconv.FormatUint(u, 10), nil
+	}
+	if f, err := engine.GetFloat(key); err == nil {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	if b, err := engine.GetBool(key); err == nil {
+		return strconv.FormatBool(b), nil
+	}
+	return "", err
+}
@@ -36,3 +36,20 @@ func (loader *FileLoader) Unload() error {
 	}
 	return loader.fileHandler.Close()
 }
+
+// loaderFiles returns the file read by the given loader, when it is a FileLoader.
+func loaderFiles(loader Loader) []string {
+	if fileLoader, ok := loader.(*FileLoader); ok {
+		return []string{fileLoader.filePath}
+	}
+	return nil
+}
+
+// describeLoader describes the source of an engine of the given format as `format:path`, when its loader is a
+// FileLoader, or just as the format otherwise.
+func describeLoader(format string, loader Loader) string {
+	if fileLoader, ok := loader.(*FileLoader); ok {
+		return format + ":" + fileLoader.filePath
+	}
+	return format
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// defaultSource describes the source of the default values, set by the `default` tag.
+const defaultSource = "default"
+
+// Report describes where the values of a config came from, as returned by Manager.PopulateWithReport.
+type Report struct {
+	Fields []FieldReport
+}
+
+// FieldReport describes where the value of a field came from. It never holds the value itself, so reports can be
+// logged even for the configs holding secrets.
+type FieldReport struct {
+	// Path is the Go path of the field, like `Servers[1].Port`.
+	Path string
+
+	// Key is the config key of the field, like `servers.1.port`.
+	Key string
+
+	// Secret reports whether the field is read from the secret engines.
+	Secret bool
+
+	// Source describes the engine the value was read from, like `env:APP_DB_HOST` (see SourceDescriber), or is
+	// `default` for the values set by the `default` tag. It is empty when no engine has the key.
+	Source string
+
+	// Missed describes the engines tried before Source, which did not have the key.
+	Missed []string
+
+	// Default reports whether the value was set by the `default` tag.
+	Default bool
+}
+
+// String formats the report as a table, with a line for each field.
+func (r *Report) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FIELD\tKEY\tSOURCE\tMISSED")
+	for _, field := range r.Fields {
+		source := field.Source
+		if source == "" {
+			source = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", field.Path, field.Key, source, strings.Join(field.Missed, ", "))
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+// PopulateWithReport populates cfg, as Populate does, also returning a report of where the value of each field came
+// from. When Populate fails, the report covers the fields read until the failure.
+func (m *Manager) PopulateWithReport(cfg interface{}) (*Report, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	report := &Report{}
+	err := m.populate(cfg, report)
+	return report, err
+}
+
+// record adds the field read from winner, or not found when winner is nil, to the report.
+func (p *populator) record(ref fieldRef, tag fieldTag, winner Engine, missed []Engine) {
+	if p.report == nil {
+		return
+	}
+	field := FieldReport{
+		Path:    ref.field,
+		Key:     ref.key,
+		Secret:  tag.secret,
+		Default: isDefaultEngine(winner),
+	}
+	if winner != nil {
+		field.Source = describeSource(winner, ref.key)
+	}
+	for _, engine := range missed {
+		field.Missed = append(field.Missed, describeSource(engine, ref.key))
+	}
+	p.report.Fields = append(p.report.Fields, field)
+}
+
+// describeSource describes where engine reads key from, see SourceDescriber.
+func describeSource(engine Engine, key string) string {
+	if describer, ok := engine.(SourceDescriber); ok {
+		return describer.DescribeSource(key)
+	}
+	return fmt.Sprintf("%T", engine)
+}
+
+// newDefaultEngine returns the engine reading the default value of key.
+func newDefaultEngine(key, value string) *stringMapEngine {
+	engine := newStringMapEngine(map[string]string{key: value}, nil)
+	engine.source = defaultSource
+	return engine
+}
+
+func isDefaultEngine(engine Engine) bool {
+	stringEngine, ok := engine.(*stringMapEngine)
+	return ok && stringEngine.source == defaultSource
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestConfigWithReport struct {
+	Database MyTestServer      `config:"db"`
+	Timeout  time.Duration     `config:"timeout" default:"30s"`
+	Password string            `config:"password,secret"`
+	Debug    *bool             `config:"debug"`
+	Labels   map[string]string `config:"labels"`
+}
+
+func TestManager_PopulateWithReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestFile(t, path, "db:\n  host: yaml.local\n  port: 5432\nlabels:\n  team: core\n")
+	t.Setenv("APP_DB_HOST", "env.local")
+	t.Setenv("APP_PASSWORD", "s3cr3t")
+	t.Setenv("APP_LABELS", "env=prod")
+
+	env := NewEnvEngine(WithPrefix("APP_"))
+	manager := NewManager()
+	manager.AddPlainEngine(&env, NewYAMLEngine(NewFileLoader(path)))
+	manager.AddSecretEngine(&env)
+
+	var cfg MyTestConfigWithReport
+	report, err := manager.PopulateWithReport(&cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "env.local", cfg.Database.Host)
+
+	assert.Equal(t, []FieldReport{
+		{Path: "Database.Host", Key: "db.host", Source: "env:APP_DB_HOST"},
+		{Path: "Database.Port", Key: "db.port", Source: "yaml:" + path, Missed: []string{"env:APP_DB_PORT"}},
+		{Path: "Timeout", Key: "timeout", Source: "default", Missed: []string{"env:APP_TIMEOUT", "yaml:" + path}, Default: true},
+		{Path: "Password", Key: "password", Secret: true, Source: "env:APP_PASSWORD"},
+		{Path: "Debug", Key: "debug", Missed: []string{"env:APP_DEBUG", "yaml:" + path}},
+		{Path: `Labels["env"]`, Key: "labels.env", Source: "env:APP_LABELS"},
+		{Path: `Labels["team"]`, Key: "labels.team", Source: "yaml:" + path, Missed: []string{"env:APP_LABELS"}},
+	}, report.Fields)
+
+	t.Run("should not hold secret values", func(t *testing.T) {
+		assert.NotContains(t, report.String(), "s3cr3t")
+		assert.NotContains(t, fmt.Sprintf("%+v", report), "s3cr3t")
+	})
+
+	t.Run("should format the report as a table", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+		require.Len(t, lines, 8)
+		assert.Equal(t, []string{"FIELD", "KEY", "SOURCE", "MISSED"}, strings.Fields(lines[0]))
+		assert.Equal(t, []string{"Database.Port", "db.port", "yaml:" + path, "env:APP_DB_PORT"}, strings.Fields(lines[2]))
+		assert.Equal(t, []string{"Debug", "debug", "-", "env:APP_DEBUG,", "yaml:" + path}, strings.Fields(lines[5]))
+	})
+}
+
+func TestManager_PopulateWithReport_failure(t *testing.T) {
+	manager := NewManager()
+	manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"host": "localhost"}))
+
+	var cfg MyTestServer
+	report, err := manager.PopulateWithReport(&cfg)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	assert.Equal(t, []FieldReport{
+		{Path: "Host", Key: "host", Source: "map"},
+		{Path: "Port", Key: "port", Missed: []string{"map"}},
+	}, report.Fields)
+}
@@ -0,0 +1,426 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema dialect of the schemas generated by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches the durations read into time.Duration fields: the ones parsed by time.ParseDuration, also
+// accepting days and weeks.
+const durationPattern = `^[-+]?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h|d|w))+)$`
+
+// JSONSchema generates a JSON Schema (draft 2020-12) describing the documents, like YAML or JSON files, from which
+// Populate reads cfg. The schema follows the `config` tags of the fields as Populate does:
+//
+//   - the `required` fields, not having a default value, are listed as required, and so are the structs with
+//     required fields, as they cannot be left out;
+//   - the `default` tags are set as the defaults, parsed into the type of the field;
+//   - the `desc` tags are set as the descriptions;
+//   - the secret fields are marked as writeOnly, as Dump masks their values;
+//   - time.Duration fields are strings like `30s` or `7d`, time.Time fields are date-times and the types
+//     implementing encoding.TextUnmarshaler or with a built-in decoder, like *url.URL, are strings;
+//   - maps of values can also be written as a single `k1=v1,k2=v2` string;
+//   - the rules of the `validate` tags are described by the matching keywords, like `minimum` for `min`, when JSON
+//     Schema has them.
+//
+// Fields of types Populate does not read are left out, and so are the keys the config does not have: as Populate
+// ignores the unknown keys, the schema allows them. Recursive types are described under `$defs`.
+//
+// The types with a decoder registered in a Manager are only described as strings by Manager.JSONSchema.
+func JSONSchema(cfg interface{}) ([]byte, error) {
+	return jsonSchema(cfg, nil)
+}
+
+// JSONSchema generates the JSON Schema of cfg as the JSONSchema function does, describing the types with a decoder
+// registered in the Manager as strings.
+func (m *Manager) JSONSchema(cfg interface{}) ([]byte, error) {
+	return jsonSchema(cfg, m.decoders)
+}
+
+func jsonSchema(cfg interface{}, decoders decoderRegistry) ([]byte, error) {
+	t := reflect.TypeOf(cfg)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || !decoders.isStructType(t) {
+		return nil, fmt.Errorf("%w: %v", ErrTypeMismatch, t)
+	}
+
+	g := &schemaGenerator{
+		decoders:    decoders,
+		hasRequired: make(map[reflect.Type]bool),
+		recursive:   make(map[reflect.Type]bool),
+		defined:     make(map[reflect.Type]bool),
+	}
+	object, _, err := g.object(t, "")
+	if err != nil {
+		return nil, err
+	}
+	schema := append(dumpMap{{"$schema", JSONSchemaDialect}}, object...)
+	if len(g.defs) > 0 {
+		schema = append(schema, dumpEntry{"$defs", g.defs})
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// schemaGenerator holds the state of a single JSONSchema call.
+type schemaGenerator struct {
+	// decoders holds the decoders registered in the Manager, whose types are strings.
+	decoders decoderRegistry
+	// stack holds the structs being described, the first one being the root.
+	stack []reflect.Type
+	// hasRequired holds whether the described structs have required fields.
+	hasRequired map[reflect.Type]bool
+	// recursive holds the structs referenced from their own fields.
+	recursive map[reflect.Type]bool
+	// defined holds the structs already described under `$defs`.
+	defined map[reflect.Type]bool
+	defs    dumpMap
+}
+
+// ref returns the schema referencing the recursive struct t.
+func (g *schemaGenerator) ref(t reflect.Type) dumpMap {
+	if t == g.stack[0] {
+		return dumpMap{{"$ref", "#"}}
+	}
+	return dumpMap{{"$ref", "#/$defs/" + t.String()}}
+}
+
+// object returns the schema of the struct t, the path being its key, and whether it has required fields.
+func (g *schemaGenerator) object(t reflect.Type, path string) (dumpMap, bool, error) {
+	if slices.Contains(g.stack, t) {
+		g.recursive[t] = true
+		return g.ref(t), g.hasRequired[t], nil
+	}
+	if g.defined[t] {
+		return g.ref(t), g.hasRequired[t], nil
+	}
+
+	g.stack = append(g.stack, t)
+	defer func() {
+		g.stack = g.stack[:len(g.stack)-1]
+	}()
+
+	properties := make(dumpMap, 0, t.NumField())
+	required := make([]string, 0)
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := parseFieldTag(t.Field(i))
+		if !ok {
+			continue
+		}
+		key := tag.name
+		if path != "" {
+			key = path + "." + tag.name
+		}
+		if tag.err != nil {
+			return nil, false, fmt.Errorf("%w: %s: %w", ErrInvalidConfigTag, key, tag.err)
+		}
+		schema, isRequired, err := g.field(t.Field(i).Type, tag, key)
+		if err != nil {
+			return nil, false, err
+		}
+		if schema == nil {
+			continue
+		}
+		if tag.description != "" {
+			schema = append(dumpMap{{"description", tag.description}}, schema...)
+		}
+		if tag.secret {
+			schema = append(schema, dumpEntry{"writeOnly", true})
+		}
+		properties = append(properties, dumpEntry{tag.name, schema})
+		if isRequired {
+			required = append(required, tag.name)
+		}
+	}
+
+	schema := dumpMap{{"type", "object"}, {"properties", properties}}
+	if len(required) > 0 {
+		schema = append(schema, dumpEntry{"required", required})
+	}
+	g.hasRequired[t] = len(required) > 0
+	if g.recursive[t] && len(g.stack) > 1 {
+		g.defined[t] = true
+		g.defs = append(g.defs, dumpEntry{t.String(), schema})
+		return g.ref(t), g.hasRequired[t], nil
+	}
+	return schema, g.hasRequired[t], nil
+}
+
+// field returns the schema of a field of type t, the path being its key, and whether it is required. The schema is
+// nil when Populate does not read fields of the type.
+func (g *schemaGenerator) field(t reflect.Type, tag fieldTag, path string) (dumpMap, bool, error) {
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+
+	var schema dumpMap
+	switch {
+	case g.decoders.isStructType(t):
+		object, hasRequired, err := g.object(t, path)
+		if isPtr {
+			return object, tag.required, err
+		}
+		return object, hasRequired, err
+	case t.Kind() == reflect.Slice && g.decoders.isStructType(derefType(t.Elem())):
+		if isPtr {
+			return nil, false, nil
+		}
+		items, _, err := g.object(derefType(t.Elem()), path)
+		return dumpMap{{"type", "array"}, {"items", items}}, tag.required, err
+	case t.Kind() == reflect.Map:
+		var err error
+		if schema, err = g.mapSchema(t, tag, path); err != nil {
+			return nil, false, err
+		}
+	case t == timeType && tag.hasTimeOptions():
+		schema = timeSchema(tag)
+	default:
+		schema = leafSchema(t, g.decoders)
+	}
+	if schema == nil {
+		return nil, false, nil
+	}
+
+	if tag.validate != "" {
+		rules, err := parseValidateTag(tag.validate)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %s: %w", ErrInvalidValidateTag, path, err)
+		}
+		if schema, err = validationSchema(schema, t, rules); err != nil {
+			return nil, false, fmt.Errorf("%w: %s: %w", ErrInvalidValidateTag, path, err)
+		}
+	}
+	if tag.hasDefault && (t.Kind() != reflect.Map || g.decoders.isLeafType(t.Elem())) {
+		value, err := schemaDefault(t, tag, g.decoders)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: invalid default: %w", path, err)
+		}
+		schema = append(schema, dumpEntry{"default", value})
+	}
+	return schema, tag.required && !tag.hasDefault, nil
+}
+
+// mapSchema returns the schema of the map type t, or nil when Populate does not read maps of the type.
+func (g *schemaGenerator) mapSchema(t reflect.Type, tag fieldTag, path string) (dumpMap, error) {
+	if t.Key().Kind() != reflect.String {
+		return nil, nil
+	}
+	switch elem := t.Elem(); {
+	case g.decoders.isStructType(elem):
+		values, _, err := g.object(elem, path)
+		return dumpMap{{"type", "object"}, {"additionalProperties", values}}, err
+	case g.decoders.isLeafType(elem):
+		values := leafSchema(elem, g.decoders)
+		if elem == timeType && tag.hasTimeOptions() {
+			values = timeSchema(tag)
+		}
+		if values == nil {
+			return nil, nil
+		}
+		return dumpMap{{"type", []string{"object", "string"}}, {"additionalProperties", values}}, nil
+	}
+	return nil, nil
+}
+
+// leafSchema returns the schema of the values of type t read from a single key, or nil when Populate does not read
+// values of the type. It follows readValue, the types read by the decoders being strings.
+func leafSchema(t reflect.Type, decoders decoderRegistry) dumpMap {
+	switch {
+	case t == timeType:
+		return dumpMap{{"type", "string"}, {"format", "date-time"}}
+	case decoders.has(t):
+		return dumpMap{{"type", "string"}}
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		// When the engine holds a value of another type, it is read according to the kind of the type.
+		if kind := scalarSchema(t); kind != nil && kind[0].value != "string" {
+			return dumpMap{{"type", []interface{}{"string", kind[0].value}}}
+		}
+		return dumpMap{{"type", "string"}}
+	case t == durationType:
+		return dumpMap{{"type", "string"}, {"pattern", durationPattern}}
+	case t.Kind() == reflect.Slice:
+		if elem := t.Elem(); elem == durationType || decoders.has(elem) {
+			return dumpMap{{"type", "array"}, {"items", leafSchema(t.Elem(), decoders)}}
+		}
+		if items := scalarSchema(t.Elem()); items != nil {
+			return dumpMap{{"type", "array"}, {"items", items}}
+		}
+		return nil
+	}
+	return scalarSchema(t)
+}
+
+// scalarSchema returns the schema of the values of kind of t, starting with their type, or nil when it is not a
+// scalar kind.
+func scalarSchema(t reflect.Type) dumpMap {
+	switch t.Kind() {
+	case reflect.String:
+		return dumpMap{{"type", "string"}}
+	case reflect.Bool:
+		return dumpMap{{"type", "boolean"}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema := dumpMap{{"type", "integer"}}
+		if bits := t.Bits(); bits < 64 {
+			schema = append(schema, dumpEntry{"minimum", -(int64(1) << (bits - 1))}, dumpEntry{"maximum", int64(1)<<(bits-1) - 1})
+		}
+		return schema
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema := dumpMap{{"type", "integer"}, {"minimum", 0}}
+		if bits := t.Bits(); bits < 64 {
+			schema = append(schema, dumpEntry{"maximum", uint64(1)<<bits - 1})
+		}
+		return schema
+	case reflect.Float32, reflect.Float64:
+		return dumpMap{{"type", "number"}}
+	}
+	return nil
+}
+
+// schemaDefault parses the default value of a field of type t, as Populate does, returning it in the form Dump writes
+// it.
+func schemaDefault(t reflect.Type, tag fieldTag, decoders decoderRegistry) (interface{}, error) {
+	const key = "default"
+	leaf := func(t reflect.Type, value string) (interface{}, error) {
+		v := reflect.New(t).Elem()
+		if t == timeType && tag.hasTimeOptions() {
+			if err := readTime(newDefaultEngine(key, value), key, tag, v); err != nil {
+				return nil, err
+			}
+			return formatTime(v.Interface().(time.Time), tag), nil
+		}
+		if err := readValue(newDefaultEngine(key, value), key, v, decoders); err != nil {
+			return nil, err
+		}
+		return dumpLeaf(v, false, decoders), nil
+	}
+	if t.Kind() != reflect.Map {
+		return leaf(t, tag.defaultValue)
+	}
+
+	entries, err := parseMapEntries(key, tag.defaultValue)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	result := make(dumpMap, 0, len(names))
+	for _, name := range names {
+		value, err := leaf(t.Elem(), entries[name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dumpEntry{name, value})
+	}
+	return result, nil
+}
+
+// timeSchema returns the schema of the time.Time values read with the `layout` or `tz` tag options.
+func timeSchema(tag fieldTag) dumpMap {
+	switch tag.layout {
+	case timeLayoutUnix, timeLayoutUnixMilli:
+		return dumpMap{{"type", "integer"}}
+	case timeLayoutDate:
+		return dumpMap{{"type", "string"}, {"format", "date"}}
+	case "":
+		return dumpMap{{"type", "string"}, {"format", "date-time"}}
+	}
+	return dumpMap{{"type", "string"}}
+}
+
+// validationSchema adds to the schema of a field of type t the keywords describing its validation rules. The rules
+// with no matching keyword, like `file_exists` or `min` for durations, are left out.
+func validationSchema(schema dumpMap, t reflect.Type, rules []validationRule) (dumpMap, error) {
+	var (
+		sizeKeywords [2]string
+		elemKeyword  string
+	)
+	switch {
+	case t.Kind() == reflect.Slice && !reflect.PointerTo(t).Implements(textUnmarshalerType):
+		sizeKeywords, elemKeyword = [2]string{"minItems", "maxItems"}, "items"
+	case t.Kind() == reflect.Map:
+		sizeKeywords, elemKeyword = [2]string{"minProperties", "maxProperties"}, "additionalProperties"
+	case t == durationType || reflect.PointerTo(t).Implements(textUnmarshalerType) || scalarSchema(t) == nil:
+		return schema, nil
+	case t.Kind() == reflect.String:
+		sizeKeywords = [2]string{"minLength", "maxLength"}
+	}
+
+	for _, rule := range rules {
+		switch {
+		case rule.name == "nonempty" && sizeKeywords[0] != "":
+			schema = schema.set(sizeKeywords[0], 1)
+		case (rule.name == "min" || rule.name == "max" || rule.name == "len") && sizeKeywords[0] != "":
+			n, err := strconv.Atoi(rule.param)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("%s does not apply to %s", rule, t)
+			}
+			if rule.name != "max" {
+				schema = schema.set(sizeKeywords[0], n)
+			}
+			if rule.name != "min" {
+				schema = schema.set(sizeKeywords[1], n)
+			}
+		case rule.name == "min" || rule.name == "max":
+			if t.Kind() == reflect.String || t.Kind() == reflect.Bool {
+				return nil, fmt.Errorf("%s does not apply to %s", rule, t)
+			}
+			bound, err := parseRuleParam(t, rule.param)
+			if err != nil {
+				return nil, err
+			}
+			keyword := "minimum"
+			if rule.name == "max" {
+				keyword = "maximum"
+			}
+			schema = schema.set(keyword, dumpLeaf(bound, false, nil))
+		case elemKeyword != "":
+			// The rules checking values apply to the elements of slices and to the values of maps.
+			if rule.name == "len" || rule.name == "nonempty" {
+				continue
+			}
+			elem, _ := schema.get(elemKeyword)
+			elemSchema, ok := elem.(dumpMap)
+			if !ok {
+				continue
+			}
+			elemSchema, err := validationSchema(slices.Clone(elemSchema), t.Elem(), []validationRule{rule})
+			if err != nil {
+				return nil, err
+			}
+			schema = schema.set(elemKeyword, elemSchema)
+		case rule.name == "oneof":
+			options := strings.Split(rule.param, "|")
+			values := make([]interface{}, len(options))
+			for i, option := range options {
+				value, err := parseRuleParam(t, option)
+				if err != nil {
+					return nil, err
+				}
+				values[i] = dumpLeaf(value, false, nil)
+			}
+			schema = schema.set("enum", values)
+		case rule.name == "regexp" && t.Kind() == reflect.String:
+			schema = schema.set("pattern", rule.param)
+		case rule.name == "url" && t.Kind() == reflect.String:
+			schema = schema.set("format", "uri")
+		}
+	}
+	return schema, nil
+}
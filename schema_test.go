@@ -0,0 +1,179 @@
+package config
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	bs "github.com/inhies/go-bytesize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestConfigWithSchema struct {
+	Name     string            `config:"name,required" desc:"Name of the application."`
+	Port     uint16            `config:"port,required" default:"8080"`
+	Ratio    float64           `config:"ratio"`
+	Debug    *bool             `config:"debug"`
+	Timeout  time.Duration     `config:"timeout" default:"1m30s"`
+	MaxBody  bs.ByteSize       `config:"max_body"`
+	StartsAt time.Time         `config:"starts_at"`
+	Hosts    []string          `config:"hosts" default:"a.local,b.local"`
+	Labels   map[string]string `config:"labels" default:"team=core"`
+	Password string            `config:"password,secret,required"`
+	Database MyTestServer      `config:"db"`
+	Replica  *MyTestServer     `config:"replica"`
+	Servers  []MyTestServer    `config:"servers,required"`
+	Ignored  chan int          `config:"ignored"`
+	Skipped  string            `config:"-"`
+}
+
+type MyTestRecursiveNode struct {
+	Name     string                `config:"name,required"`
+	Children []MyTestRecursiveNode `config:"children"`
+}
+
+type MyTestConfigWithRecursiveSchema struct {
+	Root  MyTestRecursiveNode  `config:"root"`
+	Extra *MyTestRecursiveNode `config:"extra"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Run("should describe the config struct", func(t *testing.T) {
+		data, err := JSONSchema(&MyTestConfigWithSchema{})
+		require.NoError(t, err)
+
+		pattern, err := json.Marshal(durationPattern)
+		require.NoError(t, err)
+		server := `{
+			"type": "object",
+			"properties": {
+				"host": {"type": "string"},
+				"port": {"type": "integer"}
+			},
+			"required": ["host", "port"]
+		}`
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"name": {"description": "Name of the application.", "type": "string"},
+				"port": {"type": "integer", "minimum": 0, "maximum": 65535, "default": 8080},
+				"ratio": {"type": "number"},
+				"debug": {"type": "boolean"},
+				"timeout": {"type": "string", "pattern": `+string(pattern)+`, "default": "1m30s"},
+				"max_body": {"type": ["string", "integer"]},
+				"starts_at": {"type": "string", "format": "date-time"},
+				"hosts": {"type": "array", "items": {"type": "string"}, "default": ["a.local", "b.local"]},
+				"labels": {"type": ["object", "string"], "additionalProperties": {"type": "string"}, "default": {"team": "core"}},
+				"password": {"type": "string", "writeOnly": true},
+				"db": `+server+`,
+				"replica": `+server+`,
+				"servers": {"type": "array", "items": `+server+`}
+			},
+			"required": ["name", "password", "db", "servers"]
+		}`, string(data))
+	})
+
+	t.Run("should describe recursive structs under $defs", func(t *testing.T) {
+		data, err := JSONSchema(MyTestConfigWithRecursiveSchema{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"root": {"$ref": "#/$defs/config.MyTestRecursiveNode"},
+				"extra": {"$ref": "#/$defs/config.MyTestRecursiveNode"}
+			},
+			"required": ["root"],
+			"$defs": {
+				"config.MyTestRecursiveNode": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"children": {"type": "array", "items": {"$ref": "#/$defs/config.MyTestRecursiveNode"}}
+					},
+					"required": ["name"]
+				}
+			}
+		}`, string(data))
+	})
+
+	t.Run("should match durations with the duration pattern", func(t *testing.T) {
+		pattern := regexp.MustCompile(durationPattern)
+		for _, value := range []string{"0", "30s", "1h30m", "-1.5s", "300ms", "2µs", ".5h", "7d", "1w2d12h"} {
+			_, err := parseDuration(value)
+			require.NoError(t, err)
+			assert.True(t, pattern.MatchString(value), value)
+		}
+		for _, value := range []string{"", "30", "1y", "s", "1h 30m"} {
+			assert.False(t, pattern.MatchString(value), value)
+		}
+	})
+
+	t.Run("should describe the validation rules", func(t *testing.T) {
+		var cfg struct {
+			Port     uint16            `config:"port" validate:"min=1024,max=49151"`
+			LogLevel string            `config:"log_level" validate:"oneof=debug|info"`
+			Name     string            `config:"name" validate:"nonempty,max=8,regexp=^[a-z]+$"`
+			Endpoint string            `config:"endpoint" validate:"url,file_exists"`
+			Timeout  time.Duration     `config:"timeout" validate:"min=1s"`
+			Weights  []int             `config:"weights" validate:"len=2,oneof=1|2"`
+			Labels   map[string]string `config:"labels" validate:"nonempty,oneof=a|b"`
+		}
+		data, err := JSONSchema(&cfg)
+		require.NoError(t, err)
+
+		pattern, err := json.Marshal(durationPattern)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"port": {"type": "integer", "minimum": 1024, "maximum": 49151},
+				"log_level": {"type": "string", "enum": ["debug", "info"]},
+				"name": {"type": "string", "minLength": 1, "maxLength": 8, "pattern": "^[a-z]+$"},
+				"endpoint": {"type": "string", "format": "uri"},
+				"timeout": {"type": "string", "pattern": `+string(pattern)+`},
+				"weights": {"type": "array", "items": {"type": "integer", "enum": [1, 2]}, "minItems": 2, "maxItems": 2},
+				"labels": {"type": ["object", "string"], "additionalProperties": {"type": "string", "enum": ["a", "b"]}, "minProperties": 1}
+			}
+		}`, string(data))
+	})
+
+	t.Run("should describe slices of sized numbers and durations", func(t *testing.T) {
+		var cfg struct {
+			Ports   []uint16        `config:"ports"`
+			Ratios  []float32       `config:"ratios"`
+			Backoff []time.Duration `config:"backoff" default:"1s,2s"`
+		}
+		data, err := JSONSchema(&cfg)
+		require.NoError(t, err)
+
+		pattern, err := json.Marshal(durationPattern)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"ports": {"type": "array", "items": {"type": "integer", "minimum": 0, "maximum": 65535}},
+				"ratios": {"type": "array", "items": {"type": "number"}},
+				"backoff": {"type": "array", "items": {"type": "string", "pattern": `+string(pattern)+`}, "default": ["1s", "2s"]}
+			}
+		}`, string(data))
+	})
+
+	t.Run("should fail on invalid defaults", func(t *testing.T) {
+		var cfg struct {
+			Port int `config:"port" default:"http"`
+		}
+		_, err := JSONSchema(&cfg)
+		require.ErrorContains(t, err, "port: invalid default")
+	})
+
+	t.Run("should fail on non-struct types", func(t *testing.T) {
+		_, err := JSONSchema(42)
+		require.ErrorIs(t, err, ErrTypeMismatch)
+	})
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+)
+
+type reloadOptions struct {
+	signals  []os.Signal
+	callback func(error)
+}
+
+type ReloadOption func(*reloadOptions)
+
+// WithReloadSignals sets the signals that trigger the reloads of Manager.ReloadOnSignal. It defaults to SIGHUP.
+func WithReloadSignals(signals ...os.Signal) ReloadOption {
+	return func(o *reloadOptions) {
+		o.signals = signals
+	}
+}
+
+// WithReloadCallback sets the function called after each reload of Manager.ReloadOnSignal with its error, which is nil
+// when the reload succeeds.
+func WithReloadCallback(f func(error)) ReloadOption {
+	return func(o *reloadOptions) {
+		o.callback = f
+	}
+}
+
+// liveUpdater is implemented by Live, whose values are replaced instead of populated in place.
+type liveUpdater interface {
+	update(populate func(cfg interface{}) error) error
+}
+
+// Reload unloads and loads again all the engines of the Manager and populates target with their values. The target
+// is only changed when the reload succeeds, including its Validator.
+//
+// The target can be a pointer to a config, which is overwritten, or a *Live, whose value is replaced atomically. Only
+// the latter can be read while being reloaded.
+func (m *Manager) Reload(target interface{}) error {
+	if live, ok := target.(liveUpdater); ok {
+		return live.update(m.reload)
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr {
+		return ErrConfigNotPointer
+	}
+	value := reflect.New(v.Elem().Type())
+	if err := m.reload(value.Interface()); err != nil {
+		return err
+	}
+	v.Elem().Set(value.Elem())
+	return nil
+}
+
+// reload unloads all the engines and populates cfg, which loads them again.
+func (m *Manager) reload(cfg interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, engines := range [][]Engine{m.plains, m.secrets} {
+		for _, engine := range engines {
+			if err := engine.Unload(); err != nil {
+				return err
+			}
+		}
+	}
+	return m.populate(cfg, nil)
+}
+
+// ReloadOnSignal calls Reload with target every time the process receives one of the reload signals (see
+// WithReloadSignals), until ctx is done.
+//
+// The result of each reload is passed to the callback set by WithReloadCallback and sent to the returned channel,
+// which is closed when ctx is done. The channel only keeps the result of the latest reload, dropping the ones not
+// received yet, so the callback is the way to observe all of them.
+func (m *Manager) ReloadOnSignal(ctx context.Context, target interface{}, opts ...ReloadOption) <-chan error {
+	options := reloadOptions{
+		signals:  []os.Signal{syscall.SIGHUP},
+		callback: func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, options.signals...)
+	results := make(chan error, 1)
+	go func() {
+		defer close(results)
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				err := m.Reload(target)
+				options.callback(err)
+				select {
+				case results <- err:
+				default:
+					// Replaces the result not received yet.
+					select {
+					case <-results:
+					default:
+					}
+					results <- err
+				}
+			}
+		}
+	}()
+	return results
+}
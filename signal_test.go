@@ -0,0 +1,162 @@
+//go:build !windows
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitReloadResult(t *testing.T, results <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-results:
+		return err
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "config not reloaded")
+		return nil
+	}
+}
+
+func TestManager_Reload(t *testing.T) {
+	t.Run("should reload the engines into a config pointer", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		manager := NewManager()
+		manager.AddPlainEngine(
+			NewYAMLEngine(NewFileLoader(path)),
+			NewMapEngine(map[string]interface{}{"host": "fallback"}),
+		)
+
+		var cfg MyTestServer
+		require.NoError(t, manager.Populate(&cfg))
+
+		writeTestFile(t, path, "port: 9090\n")
+		require.NoError(t, manager.Reload(&cfg))
+		assert.Equal(t, MyTestServer{Host: "fallback", Port: 9090}, cfg)
+	})
+
+	t.Run("should reload engines that were never loaded", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+		dotenvPath := filepath.Join(t.TempDir(), ".env")
+		writeTestFile(t, dotenvPath, "PORT=9090\n")
+
+		manager := NewManager()
+		manager.AddPlainEngine(
+			NewDotenvEngine(NewFileLoader(dotenvPath)),
+			NewYAMLEngine(NewFileLoader(path)),
+			NewJSONEngine(NewBytesLoader([]byte(`{}`))),
+			NewTOMLEngine(NewBytesLoader(nil)),
+		)
+
+		var cfg MyTestServer
+		require.NoError(t, manager.Reload(&cfg))
+		assert.Equal(t, MyTestServer{Host: "localhost", Port: 9090}, cfg)
+	})
+
+	t.Run("should keep the config when the reload fails", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewFileLoader(path)))
+
+		var cfg MyTestServer
+		require.NoError(t, manager.Populate(&cfg))
+
+		writeTestFile(t, path, "host: localhost\nport: -1\n")
+		require.ErrorIs(t, manager.Reload(&cfg), errMustBePositive)
+		assert.Equal(t, MyTestServer{Host: "localhost", Port: 8080}, cfg)
+	})
+
+	t.Run("should fail when the config is not a pointer", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+
+		require.ErrorIs(t, manager.Reload(MyTestServer{}), ErrConfigNotPointer)
+	})
+}
+
+func TestManager_ReloadOnSignal(t *testing.T) {
+	t.Run("should reload a Live config on the given signals", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewFileLoader(path)))
+		live, err := NewLive[MyTestServer](manager)
+		require.NoError(t, err)
+
+		var (
+			mu        sync.Mutex
+			callbacks []error
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		results := manager.ReloadOnSignal(ctx, live,
+			WithReloadSignals(syscall.SIGUSR1),
+			WithReloadCallback(func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				callbacks = append(callbacks, err)
+			}),
+		)
+
+		// Readers keep using the config while it is reloaded.
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for ctx.Err() == nil {
+				_ = live.Load().Port
+			}
+		}()
+
+		writeTestFile(t, path, "host: localhost\nport: 9090\n")
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+		require.NoError(t, waitReloadResult(t, results))
+		assert.Equal(t, 9090, live.Load().Port)
+
+		writeTestFile(t, path, "host: localhost\nport: -1\n")
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+		require.ErrorIs(t, waitReloadResult(t, results), errMustBePositive)
+		assert.Equal(t, 9090, live.Load().Port)
+
+		mu.Lock()
+		assert.Len(t, callbacks, 2)
+		mu.Unlock()
+
+		cancel()
+		<-done
+		_, ok := <-results
+		assert.False(t, ok)
+	})
+
+	t.Run("should reload on SIGHUP by default", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewFileLoader(path)))
+		var cfg MyTestServer
+		require.NoError(t, manager.Populate(&cfg))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		results := manager.ReloadOnSignal(ctx, &cfg)
+
+		writeTestFile(t, path, "host: localhost\nport: 9090\n")
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+		require.NoError(t, waitReloadResult(t, results))
+		assert.Equal(t, 9090, cfg.Port)
+	})
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// The layouts of the `layout` tag option that are not Go layouts.
+const (
+	// timeLayoutUnix reads the times as the number of seconds since the Unix epoch.
+	timeLayoutUnix = "unix"
+	// timeLayoutUnixMilli reads the times as the number of milliseconds since the Unix epoch.
+	timeLayoutUnixMilli = "unixms"
+	// timeLayoutDate reads the times as dates, like `2024-03-01`.
+	timeLayoutDate = "date"
+)
+
+// namedTimeLayouts holds the Go layouts that the `layout` tag option also accepts by name, as the options are separated
+// by commas and some of the layouts, like time.RFC1123, have commas.
+var namedTimeLayouts = map[string]string{
+	"ansic":       time.ANSIC,
+	"unixdate":    time.UnixDate,
+	"rubydate":    time.RubyDate,
+	"rfc822":      time.RFC822,
+	"rfc822z":     time.RFC822Z,
+	"rfc850":      time.RFC850,
+	"rfc1123":     time.RFC1123,
+	"rfc1123z":    time.RFC1123Z,
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"kitchen":     time.Kitchen,
+	"stamp":       time.Stamp,
+	"stampmilli":  time.StampMilli,
+	"stampmicro":  time.StampMicro,
+	"stampnano":   time.StampNano,
+	"datetime":    time.DateTime,
+	"timeonly":    time.TimeOnly,
+}
+
+// hasTimeOptions reports whether the `layout` or `tz` tag options are set, so the time.Time field is read by readTime
+// instead of as RFC 3339.
+func (tag fieldTag) hasTimeOptions() bool {
+	return tag.layout != "" || tag.tz != ""
+}
+
+// location returns the location set by the `tz` tag option, or nil when it is not set.
+func (tag fieldTag) location() (*time.Location, error) {
+	if tag.tz == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(tag.tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tz option: %w", err)
+	}
+	return loc, nil
+}
+
+// readTime reads the value of key from engine into the time.Time v, following the `layout` and `tz` options of tag.
+//
+// Strings are parsed with the layout, in the tz location when they do not have a timezone, and converted to the tz
+// location. The `unix` and `unixms` layouts also read the numbers of the engines. The native times of the engines,
+// like the YAML timestamps and the TOML datetimes, are read as they are and converted to the tz location.
+func readTime(engine Engine, key string, tag fieldTag, v reflect.Value) error {
+	loc, err := tag.location()
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+
+	raw, err := engine.GetString(key)
+	if err == nil {
+		t, err := parseTime(raw, tag.layout, loc)
+		if err != nil {
+			return fmt.Errorf("%w: %s", err, key)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	if !errors.Is(err, ErrTypeMismatch) {
+		return err
+	}
+
+	if tag.layout == timeLayoutUnix || tag.layout == timeLayoutUnixMilli {
+		n, err := engine.GetInt64(key)
+		if err == nil {
+			v.Set(reflect.ValueOf(unixTime(n, tag.layout, loc)))
+			return nil
+		}
+		if !errors.Is(err, ErrTypeMismatch) {
+			return err
+		}
+	}
+	if err := readValue(engine, key, v, nil); err != nil {
+		return err
+	}
+	if loc != nil {
+		v.Set(reflect.ValueOf(v.Interface().(time.Time).In(loc)))
+	}
+	return nil
+}
+
+// parseTime parses raw with the given layout, which can be a Go layout or one of the timeLayout constants, and
+// converts it to loc when it is not nil. An empty layout is RFC 3339.
+func parseTime(raw, layout string, loc *time.Location) (time.Time, error) {
+	switch layout {
+	case timeLayoutUnix, timeLayoutUnixMilli:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s time %q", layout, raw)
+		}
+		return unixTime(n, layout, loc), nil
+	case timeLayoutDate:
+		layout = time.DateOnly
+	case "":
+		layout = time.RFC3339
+	}
+
+	parseLoc := loc
+	if parseLoc == nil {
+		parseLoc = time.UTC
+	}
+	t, err := time.ParseInLocation(layout, raw, parseLoc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t, nil
+}
+
+// unixTime returns the time n seconds, or milliseconds for the `unixms` layout, after the Unix epoch, in loc or else
+// in UTC.
+func unixTime(n int64, layout string, loc *time.Location) time.Time {
+	t := time.Unix(n, 0)
+	if layout == timeLayoutUnixMilli {
+		t = time.UnixMilli(n)
+	}
+	if loc == nil {
+		return t.UTC()
+	}
+	return t.In(loc)
+}
+
+// formatTime returns the value written for t, read with the `layout` and `tz` options of tag, in the form readTime
+// reads it: the Unix times are numbers and the other layouts strings.
+func formatTime(t time.Time, tag fieldTag) interface{} {
+	if loc, err := tag.location(); err == nil && loc != nil {
+		t = t.In(loc)
+	}
+	switch tag.layout {
+	case timeLayoutUnix:
+		return t.Unix()
+	case timeLayoutUnixMilli:
+		return t.UnixMilli()
+	case timeLayoutDate:
+		return t.Format(time.DateOnly)
+	case "":
+		return t.Format(time.RFC3339Nano)
+	}
+	return t.Format(tag.layout)
+}
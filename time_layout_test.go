@@ -0,0 +1,179 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestConfigWithTimeLayouts struct {
+	Window    time.Time  `config:"window,layout=2006-01-02 15:04,tz=Europe/Berlin"`
+	NotAfter  time.Time  `config:"not_after,layout=unix"`
+	CreatedAt time.Time  `config:"created_at,layout=unixms"`
+	Since     time.Time  `config:"since,layout=date" default:"2024-01-01"`
+	Local     time.Time  `config:"local,tz=Europe/Berlin"`
+	Deadline  *time.Time `config:"deadline,layout=date"`
+}
+
+func TestManager_Populate_timeLayouts(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("should parse the times with the layout and tz options", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+			"window":     "2024-03-01 10:00",
+			"not_after":  "1709287200",
+			"created_at": "1709287200500",
+			"local":      "2024-03-01T10:00:00Z",
+			"deadline":   "2024-12-31",
+		}))
+
+		var cfg MyTestConfigWithTimeLayouts
+		require.NoError(t, manager.Populate(&cfg))
+		assert.True(t, time.Date(2024, 3, 1, 10, 0, 0, 0, berlin).Equal(cfg.Window), cfg.Window)
+		assert.Equal(t, berlin.String(), cfg.Window.Location().String())
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), cfg.NotAfter)
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, int(500*time.Millisecond), time.UTC), cfg.CreatedAt)
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), cfg.Since)
+		assert.True(t, time.Date(2024, 3, 1, 11, 0, 0, 0, berlin).Equal(cfg.Local), cfg.Local)
+		assert.Equal(t, 11, cfg.Local.Hour())
+		require.NotNil(t, cfg.Deadline)
+		assert.Equal(t, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), *cfg.Deadline)
+	})
+
+	t.Run("should read the numbers and native times of YAML in the tz location", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`window: 2024-03-01T10:00:00Z
+not_after: 1709287200
+created_at: 1709287200500
+`))))
+
+		var cfg MyTestConfigWithTimeLayouts
+		require.NoError(t, manager.Populate(&cfg))
+		assert.True(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC).Equal(cfg.Window), cfg.Window)
+		assert.Equal(t, berlin.String(), cfg.Window.Location().String())
+		assert.Equal(t, 11, cfg.Window.Hour())
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), cfg.NotAfter)
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, int(500*time.Millisecond), time.UTC), cfg.CreatedAt)
+		assert.Nil(t, cfg.Deadline)
+	})
+
+	t.Run("should fail naming the key when the value does not match the layout", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"since": "01/02/2024"}))
+
+		var cfg MyTestConfigWithTimeLayouts
+		err := manager.Populate(&cfg)
+		require.ErrorContains(t, err, `cannot parse "01/02/2024"`)
+		assert.ErrorContains(t, err, ": since")
+	})
+
+	t.Run("should parse the named layouts", func(t *testing.T) {
+		var cfg struct {
+			Expires  time.Time `config:"expires,layout=rfc1123"`
+			Modified time.Time `config:"modified,layout=rfc822z"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+			"expires":  "Fri, 01 Mar 2024 10:00:00 UTC",
+			"modified": "01 Mar 24 10:00 +0100",
+		}))
+
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), cfg.Expires)
+		assert.True(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC).Equal(cfg.Modified), cfg.Modified)
+	})
+
+	t.Run("should fail on layouts cut at a comma", func(t *testing.T) {
+		var cfg struct {
+			Expires time.Time `config:"expires,layout=Mon, 02 Jan 2006 15:04:05 MST"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"expires": "Fri, 01 Mar 2024 10:00:00 UTC"}))
+
+		err := manager.Populate(&cfg)
+		require.ErrorIs(t, err, ErrInvalidConfigTag)
+		assert.EqualError(t, err,
+			`invalid config tag: expires: layout "Mon" is cut at a comma, before " 02 Jan 2006 15:04:05 MST": give it by name`)
+
+		_, err = JSONSchema(&cfg)
+		require.ErrorIs(t, err, ErrInvalidConfigTag)
+	})
+
+	t.Run("should ignore the other unknown tag options", func(t *testing.T) {
+		var cfg struct {
+			Name    string    `config:"name,omitempty"`
+			Expires time.Time `config:"expires,layout=date,omitempty"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"name": "app", "expires": "2024-03-01"}))
+
+		require.NoError(t, manager.Populate(&cfg))
+		assert.Equal(t, "app", cfg.Name)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), cfg.Expires)
+
+		_, err := JSONSchema(&cfg)
+		require.NoError(t, err)
+	})
+
+	t.Run("should fail on invalid tz options", func(t *testing.T) {
+		var cfg struct {
+			At time.Time `config:"at,tz=Mars/Olympus"`
+		}
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"at": "2024-03-01T10:00:00Z"}))
+
+		require.ErrorContains(t, manager.Populate(&cfg), "at: invalid tz option")
+	})
+
+	t.Run("should dump the times in their layout", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{
+			"window":     "2024-03-01 10:00",
+			"not_after":  "1709287200",
+			"created_at": "1709287200500",
+			"local":      "2024-03-01T10:00:00Z",
+		}))
+		var cfg MyTestConfigWithTimeLayouts
+		require.NoError(t, manager.Populate(&cfg))
+
+		data, err := manager.Dump(&cfg, DumpYAML)
+		require.NoError(t, err)
+		assert.Equal(t, `window: 2024-03-01 10:00
+not_after: 1709287200
+created_at: 1709287200500
+since: "2024-01-01"
+local: "2024-03-01T11:00:00+01:00"
+`, string(data))
+
+		var dumped MyTestConfigWithTimeLayouts
+		reloaded := NewManager()
+		reloaded.AddPlainEngine(NewYAMLEngine(NewBytesLoader(data)))
+		require.NoError(t, reloaded.Populate(&dumped))
+		assert.True(t, cfg.Window.Equal(dumped.Window))
+		assert.Equal(t, cfg.NotAfter, dumped.NotAfter)
+		assert.Equal(t, cfg.CreatedAt, dumped.CreatedAt)
+		assert.Equal(t, cfg.Since, dumped.Since)
+		assert.True(t, cfg.Local.Equal(dumped.Local))
+	})
+}
+
+func TestJSONSchema_timeLayouts(t *testing.T) {
+	data, err := JSONSchema(&MyTestConfigWithTimeLayouts{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"window": {"type": "string"},
+			"not_after": {"type": "integer"},
+			"created_at": {"type": "integer"},
+			"since": {"type": "string", "format": "date", "default": "2024-01-01"},
+			"local": {"type": "string", "format": "date-time"},
+			"deadline": {"type": "string", "format": "date"}
+		}
+	}`, string(data))
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a number of bytes, read from strings with SI units, like `10MB` (10 * 1000^2 bytes), or IEC units, like
+// `2GiB` (2 * 1024^3 bytes). The units are case-insensitive and can be separated from the number by spaces. Numbers
+// without a unit are bytes, and so are the numbers held by engines like YAMLEngine.
+type ByteSize uint64
+
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1024 * Byte
+	MiB ByteSize = 1024 * KiB
+	GiB ByteSize = 1024 * MiB
+	TiB ByteSize = 1024 * GiB
+	PiB ByteSize = 1024 * TiB
+	EiB ByteSize = 1024 * PiB
+)
+
+// byteSizeUnits holds the units of ByteSize, from the largest to the smallest, so String picks the largest unit.
+var byteSizeUnits = []struct {
+	name string
+	size ByteSize
+}{
+	{"EiB", EiB}, {"EB", EB}, {"PiB", PiB}, {"PB", PB}, {"TiB", TiB}, {"TB", TB}, {"GiB", GiB}, {"GB", GB},
+	{"MiB", MiB}, {"MB", MB}, {"KiB", KiB}, {"kB", KB}, {"B", Byte},
+}
+
+// ParseByteSize parses a ByteSize, like `512`, `10MB`, `1.5 GiB` or `64KiB`. Fractions are accepted as long as they
+// make a whole number of bytes.
+func ParseByteSize(s string) (ByteSize, error) {
+	value := strings.TrimSpace(s)
+	end := strings.IndexFunc(value, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if end < 0 {
+		end = len(value)
+	}
+	number, unit := value[:end], strings.TrimSpace(value[end:])
+	size := Byte
+	if unit != "" {
+		i := -1
+		for j, u := range byteSizeUnits {
+			if strings.EqualFold(u.name, unit) {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+		}
+		size = byteSizeUnits[i].size
+	}
+
+	n, ok := new(big.Rat).SetString(number)
+	if number == "" || !ok {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	n.Mul(n, new(big.Rat).SetUint64(uint64(size)))
+	if !n.IsInt() {
+		return 0, fmt.Errorf("invalid byte size %q: not a whole number of bytes", s)
+	}
+	if !n.Num().IsUint64() {
+		return 0, fmt.Errorf("%w: %s overflows %T", ErrValueOutOfRange, s, ByteSize(0))
+	}
+	return ByteSize(n.Num().Uint64()), nil
+}
+
+// UnmarshalText parses text as ParseByteSize does.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// MarshalText formats b as String does.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// String formats b in the largest unit, SI or IEC, that holds it as a whole number, like `10MB` or `3KiB`.
+func (b ByteSize) String() string {
+	for _, unit := range byteSizeUnits {
+		if b != 0 && b%unit.size == 0 {
+			return strconv.FormatUint(uint64(b/unit.size), 10) + unit.name
+		}
+	}
+	return "0B"
+}
+
+// parseDuration parses a duration as time.ParseDuration does, also accepting days and weeks, like `7d` or `1w2d12h`. A
+// day is 24 hours and a week is 7 days.
+func parseDuration(s string) (time.Duration, error) {
+	if !strings.ContainsAny(s, "dw") {
+		return time.ParseDuration(s)
+	}
+
+	invalid := errors.New("time: invalid duration " + strconv.Quote(s))
+	isNumber := func(r rune) bool { return (r >= '0' && r <= '9') || r == '.' }
+	// The days and weeks are rewritten in hours, which time.ParseDuration reads.
+	var b strings.Builder
+	rest := s
+	if rest != "" && (rest[0] == '-' || rest[0] == '+') {
+		b.WriteByte(rest[0])
+		rest = rest[1:]
+	}
+	for rest != "" {
+		unitStart := strings.IndexFunc(rest, func(r rune) bool { return !isNumber(r) })
+		if unitStart <= 0 {
+			return 0, invalid
+		}
+		unitEnd := strings.IndexFunc(rest[unitStart:], isNumber)
+		if unitEnd < 0 {
+			unitEnd = len(rest)
+		} else {
+			unitEnd += unitStart
+		}
+		number, unit := rest[:unitStart], rest[unitStart:unitEnd]
+		rest = rest[unitEnd:]
+
+		var hours float64
+		switch unit {
+		case "d":
+			hours = 24
+		case "w":
+			hours = 7 * 24
+		default:
+			b.WriteString(number + unit)
+			continue
+		}
+		n, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, invalid
+		}
+		b.WriteString(strconv.FormatFloat(n*hours, 'f', -1, 64) + "h")
+	}
+	d, err := time.ParseDuration(b.String())
+	if err != nil {
+		return 0, invalid
+	}
+	return d, nil
+}
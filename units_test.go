@@ -0,0 +1,125 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value string
+		want  ByteSize
+	}{
+		{"0", 0},
+		{"512", 512},
+		{"512B", 512},
+		{"10MB", 10 * MB},
+		{"10mb", 10 * MB},
+		{"1kB", 1000},
+		{"64KiB", 64 * 1024},
+		{"2GiB", 2 * GiB},
+		{"1.5 GiB", 3 * GiB / 2},
+		{" 1 TB ", TB},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			value, err := ParseByteSize(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	t.Run("fail on unknown units", func(t *testing.T) {
+		_, err := ParseByteSize("10XB")
+		require.ErrorContains(t, err, `unknown unit "XB"`)
+	})
+
+	t.Run("fail on fractions of bytes", func(t *testing.T) {
+		_, err := ParseByteSize("1.5B")
+		require.ErrorContains(t, err, "not a whole number of bytes")
+	})
+
+	t.Run("fail on invalid numbers", func(t *testing.T) {
+		for _, value := range []string{"", "MB", "1.2.3MB", "-1MB"} {
+			_, err := ParseByteSize(value)
+			assert.Error(t, err, value)
+		}
+	})
+
+	t.Run("fail when the size overflows", func(t *testing.T) {
+		_, err := ParseByteSize("16EiB")
+		require.ErrorIs(t, err, ErrValueOutOfRange)
+	})
+}
+
+func TestByteSize_String(t *testing.T) {
+	for value, want := range map[ByteSize]string{
+		0:          "0B",
+		100:        "100B",
+		2000:       "2kB",
+		3 * KiB:    "3KiB",
+		10 * MB:    "10MB",
+		1536 * MiB: "1536MiB",
+		2 * GiB:    "2GiB",
+	} {
+		assert.Equal(t, want, value.String())
+		parsed, err := ParseByteSize(want)
+		require.NoError(t, err)
+		assert.Equal(t, value, parsed)
+	}
+}
+
+func Test_parseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"30s", 30 * time.Second},
+		{"1h30m", 90 * time.Minute},
+		{"7d", 7 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{"1w2d12h", (9*24 + 12) * time.Hour},
+		{"-1d", -24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			value, err := parseDuration(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	t.Run("fail on invalid durations", func(t *testing.T) {
+		for _, value := range []string{"d", "7days", "1w 2d", "7dd"} {
+			_, err := parseDuration(value)
+			assert.ErrorContains(t, err, "time: invalid duration", value)
+		}
+	})
+}
+
+func TestManager_Populate_units(t *testing.T) {
+	var cfg struct {
+		MaxBody   ByteSize      `config:"max_body"`
+		Cache     ByteSize      `config:"cache"`
+		Buffer    ByteSize      `config:"buffer"`
+		Retention time.Duration `config:"retention"`
+		Backoff   time.Duration `config:"backoff" default:"1d"`
+	}
+	manager := NewManager()
+	manager.AddPlainEngine(NewYAMLEngine(NewBytesLoader([]byte(`max_body: 10MB
+cache: 2GiB
+buffer: 4096
+retention: 2w
+`))))
+
+	require.NoError(t, manager.Populate(&cfg))
+	assert.Equal(t, 10*MB, cfg.MaxBody)
+	assert.Equal(t, 2*GiB, cfg.Cache)
+	assert.Equal(t, ByteSize(4096), cfg.Buffer)
+	assert.Equal(t, 14*24*time.Hour, cfg.Retention)
+	assert.Equal(t, 24*time.Hour, cfg.Backoff)
+}
@@ -0,0 +1,333 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// validationRule is a rule of the `validate` tag, like `min=1`.
+type validationRule struct {
+	name  string
+	param string
+	re    *regexp.Regexp
+}
+
+// parseValidateTag parses the rules of a `validate` tag, separated by commas. As patterns may have commas, the
+// `regexp` rule takes the rest of the tag, so it must be the last one.
+func parseValidateTag(tag string) ([]validationRule, error) {
+	var rules []validationRule
+	for tag != "" {
+		var token string
+		if strings.HasPrefix(tag, "regexp=") {
+			token, tag = tag, ""
+		} else {
+			token, tag, _ = strings.Cut(tag, ",")
+		}
+		name, param, hasParam := strings.Cut(token, "=")
+		rule := validationRule{name: strings.TrimSpace(name), param: param}
+		switch rule.name {
+		case "nonempty", "url", "hostport", "file_exists":
+			if hasParam {
+				return nil, fmt.Errorf("%s does not take a parameter", rule.name)
+			}
+		case "min", "max", "len", "oneof":
+			if param == "" {
+				return nil, fmt.Errorf("%s requires a parameter", rule.name)
+			}
+		case "regexp":
+			re, err := regexp.Compile(param)
+			if err != nil {
+				return nil, err
+			}
+			rule.re = re
+		default:
+			return nil, fmt.Errorf("unknown rule %q", token)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// structRules holds the rules of the `validate` tags of the fields of a struct type, by field index, and the errors of
+// the tags that are invalid.
+type structRules struct {
+	rules [][]validationRule
+	errs  []error
+}
+
+// structRulesCache holds the structRules of the struct types populated so far, so their tags are parsed once.
+var structRulesCache sync.Map // map[reflect.Type]*structRules
+
+// validationRules returns the rules of the `validate` tags of the fields of the struct type t. The tags are checked
+// against the types of their fields, so they fail whether or not the fields are set.
+func validationRules(t reflect.Type) *structRules {
+	if cached, ok := structRulesCache.Load(t); ok {
+		return cached.(*structRules)
+	}
+	r := &structRules{
+		rules: make([][]validationRule, t.NumField()),
+		errs:  make([]error, t.NumField()),
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "" {
+			continue
+		}
+		rules, err := parseValidateTag(tag)
+		for j := 0; err == nil && j < len(rules); j++ {
+			err = checkRuleType(rules[j], field.Type)
+		}
+		r.rules[i], r.errs[i] = rules, err
+	}
+	cached, _ := structRulesCache.LoadOrStore(t, r)
+	return cached.(*structRules)
+}
+
+// checkRuleType checks that the rule applies to the values of type t, as checkRule does for a value.
+func checkRuleType(rule validationRule, t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch rule.name {
+	case "nonempty":
+		return nil
+	case "min", "max", "len":
+		switch t.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map:
+			if n, err := strconv.Atoi(rule.param); err != nil || n < 0 {
+				return ruleTypeError(rule, t)
+			}
+			return nil
+		}
+		if rule.name == "len" {
+			return ruleTypeError(rule, t)
+		}
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+			reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			if _, err := parseRuleParam(t, rule.param); err != nil {
+				return ruleTypeError(rule, t)
+			}
+			return nil
+		}
+		return ruleTypeError(rule, t)
+	}
+
+	if !reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		switch t.Kind() {
+		case reflect.Slice, reflect.Map:
+			return checkRuleType(rule, t.Elem())
+		}
+	}
+	if rule.name == "oneof" {
+		for _, option := range strings.Split(rule.param, "|") {
+			if _, err := parseRuleParam(t, option); err != nil {
+				return ruleTypeError(rule, t)
+			}
+		}
+		return nil
+	}
+	if t.Kind() != reflect.String {
+		return ruleTypeError(rule, t)
+	}
+	return nil
+}
+
+func ruleTypeError(rule validationRule, t reflect.Type) error {
+	return fmt.Errorf("%s does not apply to %s", rule, t)
+}
+
+// validate checks the value v of the field referenced by ref against the rules of its `validate` tag, stopping at the
+// first rule it breaks.
+//
+// The `min`, `max`, `len` and `nonempty` rules check the length of strings, slices and maps, and `min` and `max` check
+// the value of numbers, their parameters being parsed as the field is, so `min=1s` applies to durations. The other
+// rules check each element of slices and each value of maps: `oneof` takes values separated by `|`, `regexp` a
+// pattern the value must match, and `url`, `hostport` and `file_exists` check that strings are absolute URLs,
+// `host:port` addresses and paths of existing files.
+func (p *populator) validate(ref fieldRef, rules []validationRule, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	for _, rule := range rules {
+		if err := p.checkRule(ref, rule, v); err != nil {
+			return p.fail(ref, nil, err)
+		}
+	}
+	return nil
+}
+
+// checkRule checks the value v, referenced by ref, against the given rule.
+func (p *populator) checkRule(ref fieldRef, rule validationRule, v reflect.Value) error {
+	switch rule.name {
+	case "min", "max", "len", "nonempty":
+		return checkSize(ref, rule, v)
+	}
+
+	if !reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		switch v.Kind() {
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				if err := p.checkRule(ref.index(p.keySeparator, i), rule, v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		case reflect.Map:
+			keys := v.MapKeys()
+			slices.SortFunc(keys, func(a, b reflect.Value) int {
+				return strings.Compare(a.String(), b.String())
+			})
+			for _, key := range keys {
+				if err := p.checkRule(ref.entry(p.keySeparator, key.String()), rule, v.MapIndex(key)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return checkValue(ref, rule, v)
+}
+
+// checkSize checks the length, or the value of numbers, of v against the `min`, `max`, `len` or `nonempty` rule.
+func checkSize(ref fieldRef, rule validationRule, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		length := v.Len()
+		if v.Kind() == reflect.String {
+			length = utf8.RuneCountInString(v.String())
+		}
+		if rule.name == "nonempty" {
+			if length == 0 {
+				return validationError(ref, "must not be empty")
+			}
+			return nil
+		}
+		n, err := strconv.Atoi(rule.param)
+		if err != nil || n < 0 {
+			return invalidRuleError(ref, rule, v)
+		}
+		switch {
+		case rule.name == "min" && length < n:
+			return validationError(ref, "must have a length of at least %d", n)
+		case rule.name == "max" && length > n:
+			return validationError(ref, "must have a length of at most %d", n)
+		case rule.name == "len" && length != n:
+			return validationError(ref, "must have a length of %d", n)
+		}
+		return nil
+	}
+
+	if rule.name == "nonempty" {
+		if v.IsZero() {
+			return validationError(ref, "must not be empty")
+		}
+		return nil
+	}
+	if rule.name == "len" {
+		return invalidRuleError(ref, rule, v)
+	}
+	bound, err := parseRuleParam(v.Type(), rule.param)
+	if err != nil {
+		return invalidRuleError(ref, rule, v)
+	}
+	var c int
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c = cmp.Compare(v.Int(), bound.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		c = cmp.Compare(v.Uint(), bound.Uint())
+	case reflect.Float32, reflect.Float64:
+		c = cmp.Compare(v.Float(), bound.Float())
+	default:
+		return invalidRuleError(ref, rule, v)
+	}
+	switch {
+	case rule.name == "min" && c < 0:
+		return validationError(ref, "must be at least %s", rule.param)
+	case rule.name == "max" && c > 0:
+		return validationError(ref, "must be at most %s", rule.param)
+	}
+	return nil
+}
+
+// checkValue checks the value v against the `oneof`, `regexp`, `url`, `hostport` or `file_exists` rule.
+func checkValue(ref fieldRef, rule validationRule, v reflect.Value) error {
+	if rule.name == "oneof" {
+		options := strings.Split(rule.param, "|")
+		for _, option := range options {
+			value, err := parseRuleParam(v.Type(), option)
+			if err != nil {
+				return invalidRuleError(ref, rule, v)
+			}
+			if reflect.DeepEqual(value.Interface(), v.Interface()) {
+				return nil
+			}
+		}
+		return validationError(ref, "must be one of %s", strings.Join(options, ", "))
+	}
+
+	if v.Kind() != reflect.String {
+		return invalidRuleError(ref, rule, v)
+	}
+	s := v.String()
+	switch rule.name {
+	case "regexp":
+		if !rule.re.MatchString(s) {
+			return validationError(ref, "must match %s", rule.param)
+		}
+	case "url":
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+			return validationError(ref, "must be an absolute URL")
+		}
+	case "hostport":
+		_, port, err := net.SplitHostPort(s)
+		if err == nil {
+			_, err = strconv.ParseUint(port, 10, 16)
+		}
+		if err != nil {
+			return validationError(ref, "must be a host:port address")
+		}
+	case "file_exists":
+		if info, err := os.Stat(s); err != nil || info.IsDir() {
+			return validationError(ref, "must be an existing file")
+		}
+	}
+	return nil
+}
+
+// parseRuleParam parses the parameter of a rule into a value of type t, as Populate parses the values of type t read
+// from text sources.
+func parseRuleParam(t reflect.Type, param string) (reflect.Value, error) {
+	const key = "param"
+	value := reflect.New(t).Elem()
+	err := readValue(newStringMapEngine(map[string]string{key: param}, nil), key, value, nil)
+	return value, err
+}
+
+func validationError(ref fieldRef, format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s: %s", ErrValidationFailed, ref.path, fmt.Sprintf(format, args...))
+}
+
+func invalidRuleError(ref fieldRef, rule validationRule, v reflect.Value) error {
+	return fmt.Errorf("%w: %s: %s does not apply to %s", ErrInvalidValidateTag, ref.path, rule, v.Type())
+}
+
+// String returns the rule as it is written in the tag.
+func (rule validationRule) String() string {
+	if rule.param == "" {
+		return rule.name
+	}
+	return rule.name + "=" + rule.param
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MyTestConfigWithValidateTags struct {
+	Port     int               `config:"port" validate:"min=1,max=65535"`
+	LogLevel string            `config:"log_level" default:"info" validate:"oneof=debug|info|warn"`
+	Name     string            `config:"name" validate:"nonempty,regexp=^[a-z]{1,8}$"`
+	Code     string            `config:"code" validate:"len=3"`
+	Endpoint string            `config:"endpoint" validate:"url"`
+	Listen   *string           `config:"listen" validate:"hostport"`
+	CertFile string            `config:"cert_file" validate:"file_exists"`
+	Timeout  time.Duration     `config:"timeout" validate:"min=1s,max=1m"`
+	Hosts    []string          `config:"hosts" validate:"min=1,hostport"`
+	Labels   map[string]string `config:"labels" validate:"oneof=a|b"`
+	Servers  []MyTestServer    `config:"servers" validate:"max=2"`
+}
+
+type MyTestServerWithRules struct {
+	Host string `config:"host" validate:"nonempty"`
+	Port int    `config:"port"`
+}
+
+func (s *MyTestServerWithRules) Validate() error {
+	if s.Port <= 0 {
+		return errMustBePositive
+	}
+	return nil
+}
+
+func TestManager_Populate_Validation(t *testing.T) {
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+	writeTestFile(t, certFile, "cert")
+
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"port":      8080,
+			"name":      "app",
+			"code":      "abc",
+			"endpoint":  "https://api.example.com/v1",
+			"listen":    ":8080",
+			"cert_file": certFile,
+			"timeout":   "30s",
+			"hosts":     []interface{}{"a.local:80", "b.local:81"},
+			"labels":    map[string]interface{}{"x": "a"},
+			"servers":   []interface{}{map[string]interface{}{"host": "s0", "port": 80}},
+		}
+	}
+	populate := func(data map[string]interface{}, opts ...Option) (MyTestConfigWithValidateTags, error) {
+		manager := NewManager(opts...)
+		manager.AddPlainEngine(NewMapEngine(data))
+		var cfg MyTestConfigWithValidateTags
+		err := manager.Populate(&cfg)
+		return cfg, err
+	}
+
+	t.Run("should accept valid values", func(t *testing.T) {
+		cfg, err := populate(valid())
+		require.NoError(t, err)
+		assert.Equal(t, "info", cfg.LogLevel)
+	})
+
+	t.Run("should skip the fields that are not set", func(t *testing.T) {
+		_, err := populate(map[string]interface{}{"name": "app"})
+		require.NoError(t, err)
+	})
+
+	for _, tc := range []struct {
+		key   string
+		value interface{}
+		err   string
+	}{
+		{"port", 0, "port: must be at least 1"},
+		{"port", 70000, "port: must be at most 65535"},
+		{"log_level", "trace", "log_level: must be one of debug, info, warn"},
+		{"name", "", "name: must not be empty"},
+		{"name", "App", "name: must match ^[a-z]{1,8}$"},
+		{"code", "abcd", "code: must have a length of 3"},
+		{"endpoint", "/v1", "endpoint: must be an absolute URL"},
+		{"listen", "localhost", "listen: must be a host:port address"},
+		{"cert_file", filepath.Join(t.TempDir(), "missing.pem"), "cert_file: must be an existing file"},
+		{"timeout", "500ms", "timeout: must be at least 1s"},
+		{"hosts", []interface{}{}, "hosts: must have a length of at least 1"},
+		{"hosts", []interface{}{"a.local:80", "b.local"}, "hosts[1]: must be a host:port address"},
+		{"labels", map[string]interface{}{"x": "a", "y": "c"}, "labels.y: must be one of a, b"},
+		{"servers", []interface{}{
+			map[string]interface{}{"host": "s0", "port": 80},
+			map[string]interface{}{"host": "s1", "port": 81},
+			map[string]interface{}{"host": "s2", "port": 82},
+		}, "servers: must have a length of at most 2"},
+	} {
+		t.Run("should fail when "+tc.err, func(t *testing.T) {
+			data := valid()
+			data[tc.key] = tc.value
+			_, err := populate(data)
+			require.ErrorIs(t, err, ErrValidationFailed)
+			assert.EqualError(t, err, "validation failed: "+tc.err)
+		})
+	}
+
+	t.Run("should report every failed field when collecting all errors", func(t *testing.T) {
+		data := valid()
+		data["port"] = 0
+		data["name"] = "App"
+		_, err := populate(data, WithCollectAllErrors())
+		var errs FieldErrors
+		require.ErrorAs(t, err, &errs)
+		require.Len(t, errs, 2)
+		assert.Equal(t, "Port", errs[0].Path)
+		assert.Equal(t, "name", errs[1].Key)
+	})
+
+	t.Run("should run the Validator after the rules", func(t *testing.T) {
+		newManager := func(host string) *Manager {
+			manager := NewManager()
+			manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"host": host, "port": -1}))
+			return manager
+		}
+
+		var cfg MyTestServerWithRules
+		require.ErrorIs(t, newManager("").Populate(&cfg), ErrValidationFailed)
+		require.ErrorIs(t, newManager("localhost").Populate(&cfg), errMustBePositive)
+	})
+
+	t.Run("should fail on invalid tags", func(t *testing.T) {
+		for _, tag := range []struct {
+			cfg interface{}
+			err string
+		}{
+			{&struct {
+				Port int `config:"port" validate:"between=1"`
+			}{}, `invalid validate tag: port: unknown rule "between=1"`},
+			{&struct {
+				Port int `config:"port" validate:"len=2"`
+			}{}, "invalid validate tag: port: len=2 does not apply to int"},
+			{&struct {
+				Port int `config:"port" validate:"min=one"`
+			}{}, "invalid validate tag: port: min=one does not apply to int"},
+			{&struct {
+				Port int `config:"port" validate:"url"`
+			}{}, "invalid validate tag: port: url does not apply to int"},
+		} {
+			manager := NewManager()
+			manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"port": 1}))
+			err := manager.Populate(tag.cfg)
+			require.ErrorIs(t, err, ErrInvalidValidateTag)
+			assert.EqualError(t, err, tag.err)
+		}
+	})
+
+	t.Run("should fail on invalid tags of the fields that are not set", func(t *testing.T) {
+		for _, tag := range []struct {
+			cfg interface{}
+			err string
+		}{
+			{&struct {
+				Port int `config:"port" validate:"between=1"`
+			}{}, `invalid validate tag: port: unknown rule "between=1"`},
+			{&struct {
+				Port *int `config:"port" validate:"min=one"`
+			}{}, "invalid validate tag: port: min=one does not apply to int"},
+			{&struct {
+				Hosts []int `config:"hosts" validate:"min=1,hostport"`
+			}{}, "invalid validate tag: hosts: hostport does not apply to int"},
+			{&struct {
+				Servers []MyTestServer `config:"servers" validate:"max=2,url"`
+			}{}, "invalid validate tag: servers: url does not apply to config.MyTestServer"},
+		} {
+			manager := NewManager()
+			manager.AddPlainEngine(NewMapEngine(map[string]interface{}{}))
+			err := manager.Populate(tag.cfg)
+			require.ErrorIs(t, err, ErrInvalidValidateTag)
+			assert.EqualError(t, err, tag.err)
+		}
+	})
+}
@@ -0,0 +1,312 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	defaultWatchDebounce     = 100 * time.Millisecond
+	defaultWatchPollInterval = time.Second
+
+	// kubernetesDataDir is the symlink that Kubernetes atomically swaps to update the files of ConfigMap and Secret
+	// volumes, which are symlinks into it.
+	kubernetesDataDir = "..data"
+)
+
+// Live holds a config value kept up to date by Watch. The value is replaced atomically, so it can be read from any
+// goroutine.
+type Live[T any] struct {
+	value atomic.Pointer[T]
+
+	mu          sync.Mutex
+	nextID      int
+	subscribers []liveSubscriber[T]
+}
+
+type liveSubscriber[T any] struct {
+	id int
+	f  func(old, new *T)
+}
+
+// NewLive populates a new T using the given Manager, returning it as a Live value. The value is only updated by Watch
+// and by Manager.ReloadOnSignal.
+func NewLive[T any](m *Manager) (*Live[T], error) {
+	value := new(T)
+	if err := m.Populate(value); err != nil {
+		return nil, err
+	}
+	live := &Live[T]{}
+	live.value.Store(value)
+	return live, nil
+}
+
+// Load returns the current value. It is shared by all the readers, so it must not be modified.
+func (l *Live[T]) Load() *T {
+	return l.value.Load()
+}
+
+// Subscribe registers f to be called with the old and the new value every time a reload changes the config. The
+// subscribers are called in the order they subscribed, from the goroutine watching the files. It returns a function
+// that cancels the subscription.
+func (l *Live[T]) Subscribe(f func(old, new *T)) func() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextID++
+	id := l.nextID
+	l.subscribers = append(l.subscribers, liveSubscriber[T]{id, f})
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, s := range l.subscribers {
+			if s.id == id {
+				l.subscribers = append(l.subscribers[:i:i], l.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// update populates a new value with populate, replacing the current one only if it succeeds.
+func (l *Live[T]) update(populate func(cfg interface{}) error) error {
+	value := new(T)
+	if err := populate(value); err != nil {
+		return err
+	}
+	l.swap(value)
+	return nil
+}
+
+// swap replaces the current value with value, notifying the subscribers when they differ.
+func (l *Live[T]) swap(value *T) {
+	old := l.value.Swap(value)
+	if reflect.DeepEqual(old, value) {
+		return
+	}
+	l.mu.Lock()
+	subscribers := l.subscribers
+	l.mu.Unlock()
+	for _, s := range subscribers {
+		s.f(old, value)
+	}
+}
+
+type watchOptions struct {
+	debounce     time.Duration
+	pollInterval time.Duration
+	polling      bool
+	onError      func(error)
+}
+
+type WatchOption func(*watchOptions)
+
+// WithDebounce sets for how long Watch waits for the files to stop changing before reloading them, so the bursts of
+// writes made by editors when saving trigger a single reload. It defaults to 100ms.
+func WithDebounce(d time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.debounce = d
+	}
+}
+
+// WithPolling makes Watch poll the files for changes every interval, instead of being notified by the operating
+// system. Watch falls back to polling every second when the notifications are not available.
+func WithPolling(interval time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		o.polling = true
+		o.pollInterval = interval
+	}
+}
+
+// WithReloadErrorHandler sets the function called with the errors of the reloads, which keep the current value, and
+// with the errors of the file watching.
+func WithReloadErrorHandler(f func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = f
+	}
+}
+
+// Watch populates a new T using the given Manager and keeps it up to date with the files read by its engines (see
+// FileSource) until ctx is done. When the files change, the config is populated again into a new value, which
+// replaces the current one only if Populate succeeds, including its Validator. The subscribers of the returned Live
+// are notified when the value changes.
+//
+// The directories of the files are watched, instead of the files themselves, so files replaced by renaming another
+// file over them, as editors do, and Kubernetes ConfigMap and Secret volumes, whose `..data` symlink is swapped on
+// updates, are followed.
+func Watch[T any](ctx context.Context, m *Manager, opts ...WatchOption) (*Live[T], error) {
+	options := watchOptions{
+		debounce:     defaultWatchDebounce,
+		pollInterval: defaultWatchPollInterval,
+		onError:      func(error) {},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	live, err := NewLive[T](m)
+	if err != nil {
+		return nil, err
+	}
+
+	files := m.files()
+	if len(files) == 0 {
+		return nil, ErrNoFilesToWatch
+	}
+	w := newFileWatcher(files, options)
+	go w.run(ctx, func() {
+		if err := live.update(m.Populate); err != nil {
+			options.onError(err)
+		}
+	})
+	return live, nil
+}
+
+// files returns the files read by the engines of the Manager.
+func (m *Manager) files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var files []string
+	for _, engines := range [][]Engine{m.plains, m.secrets} {
+		for _, engine := range engines {
+			if source, ok := engine.(FileSource); ok {
+				files = append(files, source.Files()...)
+			}
+		}
+	}
+	return files
+}
+
+// fileWatcher reports the changes of a set of files, using fsnotify or, when it is not available, polling.
+type fileWatcher struct {
+	watchOptions
+	files    map[string]bool
+	states   map[string]os.FileInfo
+	notifier *fsnotify.Watcher
+}
+
+func newFileWatcher(files []string, options watchOptions) *fileWatcher {
+	w := &fileWatcher{
+		watchOptions: options,
+		files:        make(map[string]bool, len(files)),
+	}
+	for _, file := range files {
+		w.files[filepath.Clean(file)] = true
+	}
+	if !w.polling {
+		notifier, err := w.notify()
+		if err != nil {
+			options.onError(err)
+			w.polling = true
+		}
+		w.notifier = notifier
+	}
+	if w.polling {
+		// The files are stat'ed before returning, so the changes made as soon as Watch returns are not missed.
+		w.states = w.stat()
+	}
+	return w
+}
+
+// notify starts watching the directories of the files.
+func (w *fileWatcher) notify() (*fsnotify.Watcher, error) {
+	notifier, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	dirs := make(map[string]bool)
+	for file := range w.files {
+		dir := filepath.Dir(file)
+		if dirs[dir] {
+			continue
+		}
+		dirs[dir] = true
+		if err := notifier.Add(dir); err != nil {
+			_ = notifier.Close()
+			return nil, err
+		}
+	}
+	return notifier, nil
+}
+
+// run calls reload once the files stop changing for the debounce duration, until ctx is done.
+func (w *fileWatcher) run(ctx context.Context, reload func()) {
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+		tick   <-chan time.Time
+		fire   <-chan time.Time
+	)
+	if w.notifier != nil {
+		defer func() {
+			_ = w.notifier.Close()
+		}()
+		events, errs = w.notifier.Events, w.notifier.Errors
+	} else {
+		ticker := time.NewTicker(w.pollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			if w.isWatched(event.Name) {
+				fire = time.After(w.debounce)
+			}
+		case err := <-errs:
+			w.onError(err)
+		case <-tick:
+			if states := w.stat(); !sameFileStates(w.states, states) {
+				w.states = states
+				fire = time.After(w.debounce)
+			}
+		case <-fire:
+			fire = nil
+			reload()
+		}
+	}
+}
+
+// isWatched reports whether changes to the given file may change the watched files.
+func (w *fileWatcher) isWatched(name string) bool {
+	return w.files[filepath.Clean(name)] || filepath.Base(name) == kubernetesDataDir
+}
+
+// stat returns the state of the watched files, following symlinks. Files that cannot be read have a nil state.
+func (w *fileWatcher) stat() map[string]os.FileInfo {
+	states := make(map[string]os.FileInfo, len(w.files))
+	for file := range w.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			states[file] = nil
+			continue
+		}
+		states[file] = info
+	}
+	return states
+}
+
+func sameFileStates(a, b map[string]os.FileInfo) bool {
+	for file, x := range a {
+		y := b[file]
+		if x == nil || y == nil {
+			if x != y {
+				return false
+			}
+			continue
+		}
+		if !os.SameFile(x, y) || !x.ModTime().Equal(y.ModTime()) || x.Size() != y.Size() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type watchTestChange struct {
+	old, new *MyTestServer
+}
+
+// watchTestServer watches the YAML file path as a MyTestServer, returning the Live value and the channel where its
+// changes are sent.
+func watchTestServer(t *testing.T, path string, opts ...WatchOption) (*Live[MyTestServer], <-chan watchTestChange) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	manager := NewManager()
+	manager.AddPlainEngine(NewYAMLEngine(NewFileLoader(path)))
+
+	live, err := Watch[MyTestServer](ctx, manager, opts...)
+	require.NoError(t, err)
+
+	changes := make(chan watchTestChange, 16)
+	live.Subscribe(func(old, new *MyTestServer) {
+		changes <- watchTestChange{old, new}
+	})
+	return live, changes
+}
+
+func waitWatchTestChange(t *testing.T, changes <-chan watchTestChange) watchTestChange {
+	t.Helper()
+	select {
+	case change := <-changes:
+		return change
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "config not reloaded")
+		return watchTestChange{}
+	}
+}
+
+func assertNoWatchTestChange(t *testing.T, changes <-chan watchTestChange, d time.Duration) {
+	t.Helper()
+	select {
+	case change := <-changes:
+		assert.Failf(t, "unexpected reload", "%+v", *change.new)
+	case <-time.After(d):
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("should reload the config when the file changes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		live, changes := watchTestServer(t, path)
+		assert.Equal(t, MyTestServer{Host: "localhost", Port: 8080}, *live.Load())
+
+		writeTestFile(t, path, "host: localhost\nport: 9090\n")
+
+		change := waitWatchTestChange(t, changes)
+		assert.Equal(t, 8080, change.old.Port)
+		assert.Equal(t, 9090, change.new.Port)
+		assert.Same(t, change.new, live.Load())
+	})
+
+	t.Run("should reload once for a burst of writes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		live, changes := watchTestServer(t, path, WithDebounce(300*time.Millisecond))
+
+		for port := 1; port <= 5; port++ {
+			writeTestFile(t, path, fmt.Sprintf("host: localhost\nport: %d\n", 8080+port))
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		change := waitWatchTestChange(t, changes)
+		assert.Equal(t, 8080, change.old.Port)
+		assert.Equal(t, 8085, change.new.Port)
+		assertNoWatchTestChange(t, changes, 500*time.Millisecond)
+		assert.Equal(t, 8085, live.Load().Port)
+	})
+
+	t.Run("should follow files replaced by a rename", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		_, changes := watchTestServer(t, path)
+
+		for _, port := range []int{9090, 7070} {
+			tmp := filepath.Join(dir, ".config.yaml.swp")
+			writeTestFile(t, tmp, fmt.Sprintf("host: localhost\nport: %d\n", port))
+			require.NoError(t, os.Rename(tmp, path))
+
+			change := waitWatchTestChange(t, changes)
+			assert.Equal(t, port, change.new.Port)
+		}
+	})
+
+	t.Run("should follow Kubernetes volume updates", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "..2024_01_01", "config.yaml"), "host: localhost\nport: 8080\n")
+		require.NoError(t, os.Symlink("..2024_01_01", filepath.Join(dir, "..data")))
+		require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), filepath.Join(dir, "config.yaml")))
+
+		live, changes := watchTestServer(t, filepath.Join(dir, "config.yaml"))
+		assert.Equal(t, 8080, live.Load().Port)
+
+		// Kubernetes writes the new files into a new directory and atomically swaps the ..data symlink.
+		writeTestFile(t, filepath.Join(dir, "..2024_01_02", "config.yaml"), "host: localhost\nport: 9090\n")
+		require.NoError(t, os.Symlink("..2024_01_02", filepath.Join(dir, "..data_tmp")))
+		require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+		require.NoError(t, os.RemoveAll(filepath.Join(dir, "..2024_01_01")))
+
+		change := waitWatchTestChange(t, changes)
+		assert.Equal(t, 8080, change.old.Port)
+		assert.Equal(t, 9090, change.new.Port)
+	})
+
+	t.Run("should keep the current value when the new one is invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		errs := make(chan error, 16)
+		live, changes := watchTestServer(t, path, WithReloadErrorHandler(func(err error) {
+			errs <- err
+		}))
+
+		writeTestFile(t, path, "host: localhost\nport: -1\n")
+
+		select {
+		case err := <-errs:
+			require.ErrorIs(t, err, errMustBePositive)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "config not reloaded")
+		}
+		assert.Equal(t, 8080, live.Load().Port)
+		assertNoWatchTestChange(t, changes, 200*time.Millisecond)
+
+		writeTestFile(t, path, "host: localhost\nport: 9090\n")
+
+		change := waitWatchTestChange(t, changes)
+		assert.Equal(t, 8080, change.old.Port)
+		assert.Equal(t, 9090, change.new.Port)
+	})
+
+	t.Run("should not notify when the config did not change", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		_, changes := watchTestServer(t, path)
+
+		writeTestFile(t, path, "# same config\nhost: localhost\nport: 8080\n")
+		assertNoWatchTestChange(t, changes, 500*time.Millisecond)
+	})
+
+	t.Run("should poll the files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		_, changes := watchTestServer(t, path, WithPolling(20*time.Millisecond), WithDebounce(10*time.Millisecond))
+
+		writeTestFile(t, path, "host: localhost\nport: 9090\n")
+
+		change := waitWatchTestChange(t, changes)
+		assert.Equal(t, 9090, change.new.Port)
+	})
+
+	t.Run("should stop watching when the context is done", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\nport: 8080\n")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewFileLoader(path)))
+		live, err := Watch[MyTestServer](ctx, manager)
+		require.NoError(t, err)
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+
+		writeTestFile(t, path, "host: localhost\nport: 9090\n")
+		time.Sleep(300 * time.Millisecond)
+		assert.Equal(t, 8080, live.Load().Port)
+	})
+
+	t.Run("should fail when no engine reads files", func(t *testing.T) {
+		manager := NewManager()
+		manager.AddPlainEngine(NewMapEngine(map[string]interface{}{"host": "localhost", "port": 8080}))
+
+		_, err := Watch[MyTestServer](context.Background(), manager)
+		require.ErrorIs(t, err, ErrNoFilesToWatch)
+	})
+
+	t.Run("should fail when the config cannot be populated", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeTestFile(t, path, "host: localhost\n")
+
+		manager := NewManager()
+		manager.AddPlainEngine(NewYAMLEngine(NewFileLoader(path)))
+
+		_, err := Watch[MyTestServer](context.Background(), manager)
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestLive_Subscribe(t *testing.T) {
+	live := &Live[MyTestServer]{}
+	live.value.Store(&MyTestServer{Port: 1})
+
+	var calls []string
+	unsubscribeA := live.Subscribe(func(old, new *MyTestServer) {
+		calls = append(calls, "a")
+	})
+	live.Subscribe(func(old, new *MyTestServer) {
+		calls = append(calls, "b")
+	})
+
+	live.swap(&MyTestServer{Port: 2})
+	unsubscribeA()
+	live.swap(&MyTestServer{Port: 3})
+	live.swap(&MyTestServer{Port: 3})
+
+	assert.Equal(t, []string{"a", "b", "b"}, calls)
+	assert.Equal(t, 3, live.Load().Port)
+}